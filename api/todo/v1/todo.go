@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/model"
+)
+
+type Todo struct {
+	TodoId     string      `json:"todoId" dc:"待办ID"`
+	RequestId  string      `json:"requestId" dc:"所属任务的请求ID"`
+	TaskTitle  string      `json:"taskTitle" dc:"所属任务标题"`
+	FileName   string      `json:"fileName" dc:"所属任务文件名"`
+	Content    string      `json:"content" dc:"待办内容"`
+	Executor   string      `json:"executor" dc:"会议中识别到的执行人（原文）"`
+	Deadline   string      `json:"deadline" dc:"会议中识别到的截止时间（原文）"`
+	SentenceId string      `json:"sentenceId" dc:"最相近的转写句子ID，找不到时为空"`
+	StartTime  *int64      `json:"startTime" dc:"该句子的开始时间（毫秒），可用于跳转播放。找不到句子时为 null"`
+	Assignee   string      `json:"assignee" dc:"负责人 UPN"`
+	DueDate    string      `json:"dueDate" dc:"截止日期 YYYY-MM-DD，未设置时为空"`
+	Done       bool        `json:"done" dc:"是否已完成"`
+	DoneAt     *gtime.Time `json:"doneAt" dc:"完成时间"`
+	DoneBy     string      `json:"doneBy" dc:"标记完成的用户"`
+	UpdatedBy  string      `json:"updatedBy" dc:"最后修改人"`
+	UpdatedAt  *gtime.Time `json:"updatedAt" dc:"最后修改时间"`
+	CreatedAt  *gtime.Time `json:"createdAt" dc:"创建时间"`
+}
+
+// 待办列表和 ICS 导出共用的筛选条件
+type TodoFilter struct {
+	Status      string `json:"status" d:"open" v:"in:open,done,all" dc:"状态：open 未完成（默认）、done 已完成、all 全部"`
+	Assignee    string `json:"assignee" dc:"负责人 UPN，传入 me 表示当前用户"`
+	RequestId   string `json:"request_id" dc:"只看某个任务的待办"`
+	WorkspaceId string `json:"workspace_id" dc:"只看某个团队空间的待办"`
+	DueBefore   string `json:"due_before" v:"date" dc:"截止日期早于（不含），格式 YYYY-MM-DD"`
+}
+
+type GetTodoListReq struct {
+	g.Meta `path:"/list" method:"get" summary:"获取待办列表" dc:"返回当前用户可见的所有会议中的待办，以及指派给当前用户的待办"`
+	TodoFilter
+	Sort   string `json:"sort" d:"due" v:"in:due,created" dc:"排序：due 按截止日期升序（默认，未设置截止日期的排在最后）、created 按创建时间倒序"`
+	Cursor string `json:"cursor" dc:"分页游标，为空表示第一页。使用上一次返回的 nextCursor / prevCursor 翻页"`
+	Limit  int    `json:"limit" d:"50" v:"min:1|max:100" dc:"本次请求返回的数据条数"`
+	Total  string `json:"total" d:"exact" v:"in:none,exact,estimate" dc:"是否返回总数。none：不返回；exact：精确总数；estimate：估算总数"`
+}
+type GetTodoListRes struct {
+	model.PageInfo
+	Todos []Todo `json:"todos" dc:"待办列表"`
+}
+
+// 修改待办。只修改传入的字段
+type UpdateTodoReq struct {
+	g.Meta   `path:"/{todo_id}" method:"patch" summary:"修改待办" dc:"需要任务的 editor 及以上权限；负责人本人也可以标记完成"`
+	TodoId   string  `json:"todo_id" v:"required" dc:"待办ID"`
+	Done     *bool   `json:"done" dc:"是否已完成"`
+	Assignee *string `json:"assignee" v:"max-length:200" dc:"负责人 UPN，空字符串表示取消指派"`
+	DueDate  *string `json:"dueDate" dc:"截止日期 YYYY-MM-DD，空字符串表示清除"`
+}
+type UpdateTodoRes Todo
+
+type ExportTodoIcsReq struct {
+	g.Meta `path:"/ics" method:"get" mime:"text/calendar" summary:"导出待办日历" dc:"把设置了截止日期的待办导出为 iCalendar（.ics）文件，每条待办是截止日期当天的全天事件"`
+	TodoFilter
+}
+type ExportTodoIcsRes struct{}
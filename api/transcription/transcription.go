@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/api/transcription/v2"
 )
 
 type ITranscriptionV1 interface {
@@ -16,7 +17,35 @@ type ITranscriptionV1 interface {
 	GetTaskList(ctx context.Context, req *v1.GetTaskListReq) (res *v1.GetTaskListRes, err error)
 	Search(ctx context.Context, req *v1.SearchReq) (res *v1.SearchRes, err error)
 	GetTask(ctx context.Context, req *v1.GetTaskReq) (res *v1.GetTaskRes, err error)
+	UpdateTask(ctx context.Context, req *v1.UpdateTaskReq) (res *v1.UpdateTaskRes, err error)
 	DeleteTask(ctx context.Context, req *v1.DeleteTaskReq) (res *v1.DeleteTaskRes, err error)
 	QueryTaskList(ctx context.Context, req *v1.QueryTaskListReq) (res *v1.QueryTaskListRes, err error)
 	GetFileURL(ctx context.Context, req *v1.GetFileURLReq) (res *v1.GetFileURLRes, err error)
+	MoveTask(ctx context.Context, req *v1.MoveTaskReq) (res *v1.MoveTaskRes, err error)
+	ShareTask(ctx context.Context, req *v1.ShareTaskReq) (res *v1.ShareTaskRes, err error)
+	GetShareList(ctx context.Context, req *v1.GetShareListReq) (res *v1.GetShareListRes, err error)
+	DeleteShare(ctx context.Context, req *v1.DeleteShareReq) (res *v1.DeleteShareRes, err error)
+	CreateShareLink(ctx context.Context, req *v1.CreateShareLinkReq) (res *v1.CreateShareLinkRes, err error)
+	RevokeShareLink(ctx context.Context, req *v1.RevokeShareLinkReq) (res *v1.RevokeShareLinkRes, err error)
+	GetSharedTask(ctx context.Context, req *v1.GetSharedTaskReq) (res *v1.GetSharedTaskRes, err error)
+	ExportTask(ctx context.Context, req *v1.ExportTaskReq) (res *v1.ExportTaskRes, err error)
+	GetMinutes(ctx context.Context, req *v1.GetMinutesReq) (res *v1.GetMinutesRes, err error)
+	GetSpeakers(ctx context.Context, req *v1.GetSpeakersReq) (res *v1.GetSpeakersRes, err error)
+	SetSpeakers(ctx context.Context, req *v1.SetSpeakersReq) (res *v1.SetSpeakersRes, err error)
+	SaveCorrections(ctx context.Context, req *v1.SaveCorrectionsReq) (res *v1.SaveCorrectionsRes, err error)
+	GetRevisionList(ctx context.Context, req *v1.GetRevisionListReq) (res *v1.GetRevisionListRes, err error)
+	RevertRevision(ctx context.Context, req *v1.RevertRevisionReq) (res *v1.RevertRevisionRes, err error)
+	SearchTask(ctx context.Context, req *v1.SearchTaskReq) (res *v1.SearchTaskRes, err error)
+	GetAnalytics(ctx context.Context, req *v1.GetAnalyticsReq) (res *v1.GetAnalyticsRes, err error)
+	ReprocessTask(ctx context.Context, req *v1.ReprocessTaskReq) (res *v1.ReprocessTaskRes, err error)
+	GetTaskVersionList(ctx context.Context, req *v1.GetTaskVersionListReq) (res *v1.GetTaskVersionListRes, err error)
+	ActivateTaskVersion(ctx context.Context, req *v1.ActivateTaskVersionReq) (res *v1.ActivateTaskVersionRes, err error)
+	GetTaskClip(ctx context.Context, req *v1.GetTaskClipReq) (res *v1.GetTaskClipRes, err error)
+	GetTaskMedia(ctx context.Context, req *v1.GetTaskMediaReq) (res *v1.GetTaskMediaRes, err error)
+	GetTaskWaveform(ctx context.Context, req *v1.GetTaskWaveformReq) (res *v1.GetTaskWaveformRes, err error)
+}
+
+type ITranscriptionV2 interface {
+	GetTask(ctx context.Context, req *v2.GetTaskReq) (res *v2.GetTaskRes, err error)
+	Search(ctx context.Context, req *v2.SearchReq) (res *v2.SearchRes, err error)
 }
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type Share struct {
+	Id          int64       `json:"id" dc:"共享记录ID"`
+	RequestId   string      `json:"requestId" dc:"请求 ID"`
+	GranteeType string      `json:"granteeType" dc:"被授权对象类型。user：用户；group：用户组"`
+	Grantee     string      `json:"grantee" dc:"被授权对象。用户 UPN 或用户组名"`
+	Role        string      `json:"role" dc:"权限。viewer：只读；editor：可编辑"`
+	CreatedBy   string      `json:"createdBy" dc:"授权人 UPN"`
+	CreatedAt   *gtime.Time `json:"createdAt" dc:"创建时间"`
+}
+
+type ShareLink struct {
+	Token       string      `json:"token" dc:"分享链接 token，通过 /share/{token} 访问"`
+	RequestId   string      `json:"requestId" dc:"请求 ID"`
+	HasPassword bool        `json:"hasPassword" dc:"是否需要访问密码"`
+	ExpiresAt   *gtime.Time `json:"expiresAt" dc:"过期时间，为空表示永不过期"`
+	RevokedAt   *gtime.Time `json:"revokedAt" dc:"撤销时间，为空表示未撤销"`
+	CreatedBy   string      `json:"createdBy" dc:"创建人 UPN"`
+	CreatedAt   *gtime.Time `json:"createdAt" dc:"创建时间"`
+}
+
+// 共享任务给用户或用户组。重复共享给同一对象时更新权限。
+type ShareTaskReq struct {
+	g.Meta      `path:"/task/{request_id}/share" method:"post" summary:"共享任务" dc:"仅任务拥有者可操作"`
+	RequestId   string `json:"request_id" v:"required" dc:"请求ID"`
+	GranteeType string `json:"grantee_type" d:"user" v:"in:user,group" dc:"被授权对象类型。user：用户；group：用户组"`
+	Grantee     string `json:"grantee" v:"required" dc:"被授权对象。用户 UPN 或用户组名"`
+	Role        string `json:"role" d:"viewer" v:"in:viewer,editor" dc:"权限。viewer：只读；editor：可编辑"`
+}
+type ShareTaskRes Share
+
+type GetShareListReq struct {
+	g.Meta    `path:"/task/{request_id}/share" method:"get" summary:"获取任务共享列表" dc:"仅任务拥有者可查看"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+}
+type GetShareListRes struct {
+	Shares []Share     `json:"shares" dc:"共享给用户 / 用户组的记录"`
+	Links  []ShareLink `json:"links" dc:"公开分享链接"`
+}
+
+type DeleteShareReq struct {
+	g.Meta    `path:"/task/{request_id}/share/{share_id}" method:"delete" summary:"取消共享"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+	ShareId   int64  `json:"share_id" v:"required" dc:"共享记录ID"`
+}
+type DeleteShareRes struct {
+	Success bool `json:"success" dc:"是否取消成功"`
+}
+
+type CreateShareLinkReq struct {
+	g.Meta    `path:"/task/{request_id}/share-link" method:"post" summary:"创建公开分享链接" dc:"任何人持有链接（和密码）即可只读查看转写、全文总结和章节总结"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+	ExpiresIn int64  `json:"expires_in" d:"604800" v:"min:0" dc:"有效期（秒），默认 7 天。为 0 时永不过期"`
+	Password  string `json:"password" v:"max-length:64" dc:"访问密码，可选"`
+}
+type CreateShareLinkRes ShareLink
+
+type RevokeShareLinkReq struct {
+	g.Meta    `path:"/task/{request_id}/share-link/{token}" method:"delete" summary:"撤销公开分享链接"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+	Token     string `json:"token" v:"required" dc:"分享链接 token"`
+}
+type RevokeShareLinkRes struct {
+	Success bool `json:"success" dc:"是否撤销成功"`
+}
+
+// 通过公开分享链接访问任务，无需登录。
+type GetSharedTaskReq struct {
+	g.Meta   `path:"/share/{token}" method:"get" summary:"通过分享链接查看任务" dc:"访问密码可通过 password 参数或 X-Share-Password 请求头传入"`
+	Token    string `json:"token" v:"required" dc:"分享链接 token"`
+	Password string `json:"password" dc:"访问密码"`
+}
+
+// SharedTask 是 Task 的只读子集，只暴露转写、全文总结和章节总结。
+type SharedTask struct {
+	RequestId              string      `json:"requestId" dc:"请求 ID"`
+	FileName               string      `json:"fileName" dc:"文件名称"`
+	Status                 string      `json:"status" dc:"任务状态"`
+	CreatedAt              *gtime.Time `json:"createdAt" dc:"创建时间"`
+	ExpiresAt              *gtime.Time `json:"expiresAt" dc:"分享链接过期时间"`
+	AudioTranscriptionFile *gjson.Json `json:"audioTranscriptionFile" dc:"语音转写信息"`
+	ChapterFile            *gjson.Json `json:"chapterFile" dc:"章节总结信息"`
+	SummarizationFile      *gjson.Json `json:"summarizationFile" dc:"全文总结信息"`
+}
+type GetSharedTaskRes SharedTask
@@ -4,11 +4,14 @@ import (
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/model"
 )
 
 // 文件上传API（支持单文件和多文件）
 type UploadFileReq struct {
-	g.Meta `path:"/file/upload" method:"post" summary:"上传文件" dc:"使用 multipart/form-data 方式上传（可批量，并行处理）。字段名是 files。"`
+	g.Meta      `path:"/file/upload" method:"post" summary:"上传文件" dc:"使用 multipart/form-data 方式上传（可批量，并行处理）。字段名是 files。"`
+	WorkspaceId string `json:"workspace_id" dc:"上传到的团队空间ID，为空时上传到个人空间。需要 editor 及以上角色"`
 }
 type UploadFileRes struct {
 	TaskMetas []TaskMeta  `json:"taskMetas" dc:"成功上传的任务元数据列表"`
@@ -72,12 +75,19 @@ type TaskSubmitParams struct {
 }
 
 type TaskMeta struct {
-	RequestId  string      `json:"requestId" dc:"请求 ID"`
-	Owner      string      `json:"owner" dc:"拥有者 UPN"`
-	FileInfo   *gjson.Json `json:"fileInfo" dc:"文件信息"`
-	Status     string      `json:"status" dc:"任务状态"`
-	TaskParams *gjson.Json `json:"taskParams" dc:"任务参数"`
-	CreatedAt  *gtime.Time `json:"createdAt" dc:"创建时间"`
+	RequestId   string      `json:"requestId" dc:"请求 ID"`
+	Owner       string      `json:"owner" dc:"拥有者 UPN"`
+	WorkspaceId string      `json:"workspaceId" dc:"所属团队空间ID，为空表示个人空间"`
+	Title       string      `json:"title" dc:"标题，为空时客户端可使用 fileInfo.filename"`
+	Description string      `json:"description" dc:"描述"`
+	Tags        []string    `json:"tags" dc:"标签"`
+	Folder      string      `json:"folder" dc:"文件夹"`
+	Duration    int64       `json:"duration" dc:"时长（毫秒），上传时由 ffprobe 探测，探测不到时为转写最后一句的结束时间，未知时为 0"`
+	FileInfo    *gjson.Json `json:"fileInfo" dc:"文件信息。media_info 为上传时探测到的媒体信息 {duration, format_name, bit_rate, streams}"`
+	Status      string      `json:"status" dc:"任务状态。pending / uploaded / submitted / running / success / partial_success / failed"`
+	FetchErrors *gjson.Json `json:"fetchErrors" dc:"下载失败的结果文件及原因 {字段名: {attempts, status, message}}，status 为 partial_success 时不为空"`
+	TaskParams  *gjson.Json `json:"taskParams" dc:"任务参数"`
+	CreatedAt   *gtime.Time `json:"createdAt" dc:"创建时间"`
 }
 
 type Task struct {
@@ -89,31 +99,98 @@ type Task struct {
 	TranslationFile           *gjson.Json `json:"translationFile" dc:"翻译信息"`
 }
 
+// 列表接口共用的分页参数。cursor 为上一次返回的 nextCursor / prevCursor，不透明且带签名。
+type PageReq struct {
+	Cursor string `json:"cursor" dc:"分页游标，为空表示第一页。使用上一次返回的 nextCursor 翻到下一页，prevCursor 翻到上一页。游标与排序方式绑定"`
+	Total  string `json:"total" d:"exact" v:"in:none,exact,estimate" dc:"是否返回总数。none：不返回；exact：精确总数；estimate：最多统计 1000 条，超过时 totalEstimated 为 true"`
+}
+
+// 任务列表和搜索共用的筛选条件
+type TaskListFilter struct {
+	Status      []string    `json:"status" dc:"按任务状态筛选，可多选"`
+	CreatedFrom *gtime.Time `json:"created_from" dc:"创建时间起（含）"`
+	CreatedTo   *gtime.Time `json:"created_to" dc:"创建时间止（不含）"`
+	FileType    string      `json:"file_type" v:"in:audio,video" dc:"文件类型。audio：音频；video：视频"`
+	DurationMin int64       `json:"duration_min" v:"min:0" dc:"最短时长（秒）"`
+	DurationMax int64       `json:"duration_max" v:"min:0" dc:"最长时长（秒），0 表示不限"`
+	Features    []string    `json:"features" v:"foreach|in:translation,summarization,chapter,information_extraction" dc:"需同时开启的功能。translation：翻译；summarization：全文总结；chapter：章节总结；information_extraction：信息提取"`
+	Folder      string      `json:"folder" dc:"按文件夹筛选"`
+	Tags        []string    `json:"tags" dc:"按标签筛选，需同时包含所有标签"`
+}
+
 type GetTaskListReq struct {
 	g.Meta        `path:"/list" method:"get" resEg:"resource/interface/transcription/get_task_list_res.json" summary:"获取任务列表"`
-	LastRequestID string `json:"last_request_id" d:"0" dc:"当前列表最后一条数据的RequestID，用于基于该RequestID向后分页"`
+	View          string `json:"view" d:"mine" v:"in:mine,shared" dc:"列表视图。mine：我的个人空间任务；shared：共享给我的任务。传入 workspace_id 时忽略"`
+	WorkspaceId   string `json:"workspace_id" dc:"团队空间ID，传入时返回该空间内的任务"`
+	SortBy        string `json:"sort_by" d:"created_at" v:"in:created_at,duration,title" dc:"排序字段。created_at：创建时间；duration：时长；title：标题（无标题时使用文件名）"`
+	Order         string `json:"order" d:"desc" v:"in:asc,desc" dc:"排序方向"`
+	LastRequestID string `json:"last_request_id" d:"0" dc:"已废弃，请使用 cursor。当前列表最后一条数据的RequestID，用于基于该RequestID向后分页"`
 	Limit         int    `json:"limit" d:"10" v:"min:1|max:100" dc:"本次请求返回的数据条数"`
+	PageReq
+	TaskListFilter
 }
 
 type GetTaskListRes struct {
-	Total     int        `json:"total" dc:"总条目数"`
+	model.PageInfo
 	TaskMetas []TaskMeta `json:"taskMetas" dc:"任务列表"`
 }
 
 type SearchReq struct {
-	g.Meta  `path:"/search" method:"get" summary:"搜索任务"`
-	Keyword string `json:"keyword" v:"required" dc:"关键词"`
-	Limit   int    `json:"limit" d:"20" v:"min:1|max:100" dc:"返回条数，默认20，最大100"`
+	g.Meta      `path:"/search" method:"get" summary:"搜索任务" dc:"匹配请求ID、文件名、标题、描述、标签、说话人名称，以及转写、全文总结、章节总结和待办的全文。按创建时间倒序返回前 limit 条，需要分页时使用 /transcription/v2/search"`
+	Keyword     string `json:"keyword" v:"required" dc:"关键词"`
+	WorkspaceId string `json:"workspace_id" dc:"团队空间ID，传入时在该空间内搜索，否则在个人空间内搜索"`
+	Limit       int    `json:"limit" d:"20" v:"min:1|max:100" dc:"返回条数，默认20，最大100"`
+	TaskListFilter
 }
 
-type SearchRes []TaskMeta
+type SearchRes []SearchHit
+
+// 搜索命中的任务，matches 为转写、全文总结、章节总结和待办中命中的片段
+type SearchHit struct {
+	TaskMeta
+	Matches []SearchMatch `json:"matches" dc:"命中的文本片段，每个任务最多 5 条，按 全文总结、章节、待办、转写 的顺序排列。只命中元数据时为空"`
+}
+
+type SearchMatch struct {
+	Kind       string `json:"kind" dc:"片段类型：summary 全文总结、chapter 章节总结、todo 待办、utterance 转写句子"`
+	SentenceId string `json:"sentenceId" dc:"转写句子ID，其他类型为空"`
+	Snippet    string `json:"snippet" dc:"高亮片段，已做 HTML 转义，关键词用 <mark> 包裹"`
+	StartTime  int64  `json:"startTime" dc:"开始时间（毫秒），可用于跳转播放。待办和全文总结为 0"`
+	EndTime    int64  `json:"endTime" dc:"结束时间（毫秒）"`
+}
 
 type GetTaskReq struct {
-	g.Meta    `path:"/task/{request_id}" resEg:"resource/interface/transcription/get_task_res.json" method:"get" summary:"获取任务详情"`
+	g.Meta    `path:"/task/{request_id}" resEg:"resource/interface/transcription/get_task_res.json" method:"get" summary:"获取任务详情" dc:"fields / exclude 只读取需要的部分；offset、limit、start_time、end_time 任一不为 0 时分页返回 audioTranscriptionFile 的句子，已应用转写修正和说话人映射"`
 	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+	Fields    string `json:"fields" dc:"逗号分隔的返回部分，为空表示全部。meta：任务元数据；transcription：audioTranscriptionFile；chapters：chapterFile；information：informationExtractionFile；summary：summarizationFile；translation：translationFile"`
+	Exclude   string `json:"exclude" dc:"逗号分隔的不返回的部分，取值同 fields"`
+	Offset    int    `json:"offset" v:"min:0" dc:"跳过的句数"`
+	Limit     int    `json:"limit" v:"min:0|max:2000" dc:"最多返回的句数，0 表示不限"`
+	StartTime int64  `json:"start_time" v:"min:0" dc:"只返回结束时间晚于该时间（毫秒）的句子"`
+	EndTime   int64  `json:"end_time" v:"min:0" dc:"只返回开始时间早于该时间（毫秒）的句子，0 表示不限"`
+}
+
+type GetTaskRes struct {
+	Task
+	AudioTranscriptionPage *SentencePage `json:"audioTranscriptionPage,omitempty" dc:"分页返回语音转写时的分页信息"`
+}
+
+type SentencePage struct {
+	Offset  int  `json:"offset" dc:"本页第一句在筛选结果中的序号"`
+	Total   int  `json:"total" dc:"满足时间区间的句子总数"`
+	HasMore bool `json:"hasMore" dc:"是否还有下一页"`
 }
 
-type GetTaskRes Task
+// 修改任务元数据。只修改传入的字段，传入空字符串 / 空数组表示清空。
+type UpdateTaskReq struct {
+	g.Meta      `path:"/task/{request_id}" method:"patch" summary:"修改任务元数据" dc:"需要 editor 及以上权限"`
+	RequestId   string    `json:"request_id" v:"required" dc:"请求ID"`
+	Title       *string   `json:"title" v:"max-length:200" dc:"标题"`
+	Description *string   `json:"description" v:"max-length:5000" dc:"描述"`
+	Tags        *[]string `json:"tags" dc:"标签，最多 20 个，每个最长 32 个字符"`
+	Folder      *string   `json:"folder" v:"max-length:200" dc:"文件夹。可使用 / 分隔多级目录"`
+}
+type UpdateTaskRes TaskMeta
 
 type DeleteTaskReq struct {
 	g.Meta    `path:"/task/{request_id}" method:"delete" summary:"删除任务"`
@@ -133,10 +210,287 @@ type QueryTaskListRes struct {
 }
 
 type GetFileURLReq struct {
-	g.Meta    `path:"/task/{request_id}/file" method:"get" summary:"获取文件URL"`
+	g.Meta    `path:"/task/{request_id}/file" method:"get" summary:"获取文件URL" dc:"返回 TOS 的预签名地址，1 小时后失效。网页播放请使用 /task/{request_id}/media"`
 	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
 }
 
 type GetFileURLRes struct {
 	FileURL string `json:"file_url" dc:"文件URL"`
 }
+
+// 在个人空间和团队空间之间移动任务
+type MoveTaskReq struct {
+	g.Meta      `path:"/task/{request_id}/move" method:"post" summary:"移动任务" dc:"需要对任务有 owner 权限（任务拥有者或所在空间的 admin），以及目标空间的 editor 及以上角色"`
+	RequestId   string `json:"request_id" v:"required" dc:"请求ID"`
+	WorkspaceId string `json:"workspace_id" dc:"目标团队空间ID，为空表示移回拥有者的个人空间"`
+}
+type MoveTaskRes struct {
+	Success bool `json:"success" dc:"是否移动成功"`
+}
+
+// 导出转写内容为文件，直接返回文件内容而不是 JSON
+type ExportTaskReq struct {
+	g.Meta        `path:"/task/{request_id}/export" method:"get" mime:"application/octet-stream" summary:"导出转写" dc:"返回文件下载（Content-Disposition: attachment）"`
+	RequestId     string `json:"request_id" v:"required" dc:"请求ID"`
+	Format        string `json:"format" v:"required|in:srt,vtt,txt,md,docx,json" dc:"导出格式。srt / vtt：字幕；txt：纯文本；md：Markdown；docx：Word 文档；json：分段 JSON"`
+	Mode          string `json:"mode" d:"source" v:"in:source,bilingual,target" dc:"导出模式，需开启翻译才能使用 bilingual / target。source：原文；bilingual：双语（字幕原文在上译文在下，md / docx 为双栏表格）；target：只输出译文"`
+	SpeakerLabels bool   `json:"speaker_labels" d:"true" dc:"是否输出说话人名称"`
+	Timestamps    bool   `json:"timestamps" d:"true" dc:"txt / md / docx 是否输出时间戳，字幕格式总是带时间轴"`
+	MaxLineLength int    `json:"max_line_length" d:"0" v:"min:0|max:200" dc:"字幕每行最多字符数，0 表示不换行"`
+	MergeSpeaker  bool   `json:"merge_speaker" d:"false" dc:"是否合并同一说话人连续的句子"`
+}
+type ExportTaskRes struct{}
+
+// 会议纪要，直接返回文件内容而不是 JSON
+type GetMinutesReq struct {
+	g.Meta    `path:"/task/{request_id}/minutes" method:"get" mime:"application/octet-stream" summary:"生成会议纪要" dc:"把全文总结、章节总结、待办、问答、发言统计和任务信息组合为一份纪要。模板可用字段：Title、RequestId、FileName、Date、Duration、Summary{Title,Paragraph}、Chapters[]{Title,Summary,StartTime,EndTime}、Todos[]{Content,Executor,Deadline}、QuestionAnswers[]{Question,Answer}、Speakers[]{Id,Name,Utterances,Duration,Share}、GeneratedAt；函数：md、clock、duration、percent、add"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+	Format    string `json:"format" d:"md" v:"in:md,html,docx" dc:"纪要格式。md：Markdown；html：HTML 页面；docx：Word 文档"`
+	Template  string `json:"template" dc:"任务所在团队空间的纪要模板名称，为空或 default 时使用默认模板"`
+}
+type GetMinutesRes struct{}
+
+// 任务中的说话人及其映射
+type TaskSpeaker struct {
+	Id           string `json:"id" dc:"上游说话人ID"`
+	OriginalName string `json:"originalName" dc:"上游识别的名称，例如 说话人1"`
+	Name         string `json:"name" dc:"映射后的名称，未改名时为空"`
+	Profile      string `json:"profile" dc:"关联的用户 UPN"`
+	MergeInto    string `json:"mergeInto" dc:"合并到的说话人ID，为空表示未合并"`
+	Utterances   int    `json:"utterances" dc:"上游结果中该说话人的句数"`
+}
+
+type GetSpeakersReq struct {
+	g.Meta    `path:"/task/{request_id}/speakers" method:"get" summary:"获取说话人映射"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+}
+type GetSpeakersRes struct {
+	Speakers []TaskSpeaker `json:"speakers" dc:"说话人列表，按首次发言顺序排列"`
+}
+
+type SpeakerMapping struct {
+	Id        string `json:"id" v:"required" dc:"上游说话人ID"`
+	Name      string `json:"name" v:"max-length:50" dc:"显示名称，为空时保留上游名称"`
+	Profile   string `json:"profile" v:"max-length:200" dc:"关联的用户 UPN"`
+	MergeInto string `json:"mergeInto" dc:"合并到的说话人ID，用于修正被错误拆分的说话人"`
+}
+
+// 整体替换说话人映射，传入空数组表示清除所有映射。映射在读取时应用，不修改上游原始结果
+type SetSpeakersReq struct {
+	g.Meta    `path:"/task/{request_id}/speakers" method:"put" summary:"设置说话人映射" dc:"需要 editor 及以上权限。映射会应用到任务详情、导出、纪要和搜索"`
+	RequestId string           `json:"request_id" v:"required" dc:"请求ID"`
+	Speakers  []SpeakerMapping `json:"speakers" dc:"说话人映射"`
+}
+type SetSpeakersRes GetSpeakersRes
+
+// 句子级修正，按 sentenceId 对应。字段为 null 表示保留上游结果
+type TranscriptCorrection struct {
+	SentenceId string  `json:"sentenceId" v:"required" dc:"句子ID"`
+	Content    *string `json:"content" v:"max-length:5000" dc:"修正后的文本"`
+	SpeakerId  *string `json:"speakerId" dc:"修正后的说话人ID（上游ID，不受说话人映射影响）"`
+	StartTime  *int64  `json:"startTime" dc:"修正后的开始时间（毫秒）"`
+	EndTime    *int64  `json:"endTime" dc:"修正后的结束时间（毫秒）"`
+}
+
+type TranscriptRevision struct {
+	Revision    int                    `json:"revision" dc:"修订号"`
+	Author      string                 `json:"author" dc:"修改人 UPN"`
+	Comment     string                 `json:"comment" dc:"修改说明"`
+	Corrections []TranscriptCorrection `json:"corrections" dc:"截至该修订的全部修正"`
+	CreatedAt   *gtime.Time            `json:"createdAt" dc:"修改时间"`
+}
+
+// 保存修正。修正作为覆盖层保存，每次保存生成一个新修订，上游原始结果不变
+type SaveCorrectionsReq struct {
+	g.Meta       `path:"/task/{request_id}/corrections" method:"post" summary:"修改转写" dc:"需要 editor 及以上权限。corrections 会合并到最新修订上；某一句所有字段都为 null 表示撤销该句的修正"`
+	RequestId    string                 `json:"request_id" v:"required" dc:"请求ID"`
+	BaseRevision int                    `json:"baseRevision" v:"min:0" dc:"基于的修订号，必须等于当前最新修订，防止覆盖他人的修改。没有修订时为 0"`
+	Corrections  []TranscriptCorrection `json:"corrections" v:"required" dc:"修正"`
+	Comment      string                 `json:"comment" v:"max-length:200" dc:"修改说明"`
+}
+type SaveCorrectionsRes struct {
+	Revision int `json:"revision" dc:"新修订号"`
+}
+
+type GetRevisionListReq struct {
+	g.Meta    `path:"/task/{request_id}/revisions" method:"get" summary:"获取转写修订历史"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+}
+type GetRevisionListRes struct {
+	Latest    int                  `json:"latest" dc:"最新修订号，0 表示没有修订"`
+	Revisions []TranscriptRevision `json:"revisions" dc:"修订列表，按修订号倒序"`
+}
+
+type RevertRevisionReq struct {
+	g.Meta    `path:"/task/{request_id}/revisions/{revision}/revert" method:"post" summary:"恢复到历史修订" dc:"需要 editor 及以上权限。以历史修订的内容生成一个新修订，revision 为 0 时恢复为上游原始结果"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+	Revision  int    `json:"revision" v:"min:0" dc:"要恢复到的修订号"`
+}
+type RevertRevisionRes struct {
+	Revision int `json:"revision" dc:"新修订号"`
+}
+
+// 在单个任务的转写中查找，用于跳转到关键词出现的位置
+type SearchTaskReq struct {
+	g.Meta      `path:"/task/{request_id}/search" method:"get" summary:"在转写中查找" dc:"返回所有命中的句子及其时间，已应用转写修正和说话人映射"`
+	RequestId   string `json:"request_id" v:"required" dc:"请求ID"`
+	Q           string `json:"q" v:"required|max-length:200" dc:"关键词；mode=regex 时为正则表达式（RE2 语法）"`
+	Mode        string `json:"mode" d:"ignore_case" v:"in:exact,ignore_case,regex" dc:"匹配方式：exact 精确匹配、ignore_case 不区分大小写（默认）、regex 正则"`
+	Translation bool   `json:"translation" dc:"是否同时查找译文"`
+	Context     int    `json:"context" d:"1" v:"min:0|max:5" dc:"前后各返回几句上下文，默认 1"`
+	Limit       int    `json:"limit" d:"200" v:"min:1|max:1000" dc:"最多返回的命中句数，默认 200"`
+}
+type SearchTaskRes struct {
+	Total   int             `json:"total" dc:"命中总句数（原文和译文分别计数）"`
+	Matches []TranscriptHit `json:"matches" dc:"命中的句子，按时间顺序"`
+}
+
+type TranscriptSentence struct {
+	SentenceId  string `json:"sentenceId" dc:"句子ID"`
+	SpeakerId   string `json:"speakerId" dc:"说话人ID"`
+	Speaker     string `json:"speaker" dc:"说话人名称"`
+	Content     string `json:"content" dc:"文本"`
+	Translation string `json:"translation,omitempty" dc:"译文，translation=true 时返回"`
+	StartTime   int64  `json:"startTime" dc:"开始时间（毫秒）"`
+	EndTime     int64  `json:"endTime" dc:"结束时间（毫秒）"`
+}
+
+type TranscriptHit struct {
+	TranscriptSentence
+	Field   string               `json:"field" dc:"命中的字段：content 原文、translation 译文"`
+	Offsets [][2]int             `json:"offsets" dc:"命中区间 [start, end)，按 Unicode 字符计"`
+	Before  []TranscriptSentence `json:"before" dc:"前文"`
+	After   []TranscriptSentence `json:"after" dc:"后文"`
+}
+
+type GetAnalyticsReq struct {
+	g.Meta    `path:"/task/{request_id}/analytics" method:"get" summary:"获取对话统计" dc:"发言时长、轮次、语速、最长独白、抢话次数、静默占比和发言时间线。已应用转写修正和说话人映射"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+}
+type GetAnalyticsRes struct {
+	Duration            int64              `json:"duration" dc:"会议时长（毫秒）"`
+	TalkTime            int64              `json:"talkTime" dc:"有人发言的总时长（重叠部分只计一次）"`
+	SilenceTime         int64              `json:"silenceTime" dc:"静默时长"`
+	SilenceRatio        float64            `json:"silenceRatio" dc:"静默占比（0~1）"`
+	Utterances          int                `json:"utterances" dc:"句数"`
+	Turns               int                `json:"turns" dc:"发言轮次，说话人切换一次计一轮"`
+	Interruptions       int                `json:"interruptions" dc:"抢话次数：在他人尚未说完时开始发言"`
+	Characters          int                `json:"characters" dc:"字数（不含空白和标点）"`
+	Words               int                `json:"words" dc:"词数：每个汉字计一个词，连续的字母数字计一个词"`
+	CharactersPerMinute float64            `json:"charactersPerMinute" dc:"每分钟发言字数，按发言时长计算"`
+	WordsPerMinute      float64            `json:"wordsPerMinute" dc:"每分钟发言词数，按发言时长计算"`
+	LongestMonologue    *AnalyticsTurn     `json:"longestMonologue" dc:"最长的一轮发言，没有转写时为 null"`
+	Speakers            []AnalyticsSpeaker `json:"speakers" dc:"各说话人统计，按发言时长倒序"`
+	Timeline            []AnalyticsTurn    `json:"timeline" dc:"发言时间线，每一轮发言一条，按开始时间排序"`
+}
+
+// AnalyticsSpeaker 是单个说话人的对话统计
+type AnalyticsSpeaker struct {
+	SpeakerId           string  `json:"speakerId" dc:"说话人ID"`
+	Speaker             string  `json:"speaker" dc:"说话人名称"`
+	TalkTime            int64   `json:"talkTime" dc:"发言时长"`
+	Share               float64 `json:"share" dc:"发言时长占所有人发言时长之和的比例（0~1）"`
+	Utterances          int     `json:"utterances" dc:"句数"`
+	Turns               int     `json:"turns" dc:"发言轮次"`
+	Characters          int     `json:"characters" dc:"字数"`
+	Words               int     `json:"words" dc:"词数"`
+	CharactersPerMinute float64 `json:"charactersPerMinute" dc:"每分钟字数"`
+	WordsPerMinute      float64 `json:"wordsPerMinute" dc:"每分钟词数"`
+	LongestMonologue    int64   `json:"longestMonologue" dc:"最长一轮发言的时长"`
+	Interruptions       int     `json:"interruptions" dc:"抢话次数"`
+	Interrupted         int     `json:"interrupted" dc:"被抢话次数"`
+}
+
+// AnalyticsTurn 是同一说话人连续的一轮发言
+type AnalyticsTurn struct {
+	SpeakerId  string `json:"speakerId" dc:"说话人ID"`
+	Speaker    string `json:"speaker" dc:"说话人名称"`
+	StartTime  int64  `json:"startTime" dc:"开始时间"`
+	EndTime    int64  `json:"endTime" dc:"结束时间"`
+	Utterances int    `json:"utterances" dc:"句数"`
+}
+
+// 结果版本：每次提交火山云处理产生一个版本，任务详情、导出等接口返回当前版本的结果
+type TaskVersion struct {
+	Version      int              `json:"version" dc:"版本号，首次提交为 1"`
+	Current      bool             `json:"current" dc:"是否为当前版本"`
+	Status       string           `json:"status" dc:"处理状态：submitted、running、success、partial_success、failed"`
+	Params       TaskSubmitParams `json:"params" dc:"任务参数"`
+	FetchErrors  *gjson.Json      `json:"fetchErrors" dc:"下载失败的结果文件及原因，status 为 partial_success 时不为空"`
+	Duration     int64            `json:"duration" dc:"时长（毫秒）"`
+	AutoActivate bool             `json:"autoActivate" dc:"处理成功后是否自动设为当前版本"`
+	CreatedBy    string           `json:"createdBy" dc:"提交人 UPN"`
+	CreatedAt    *gtime.Time      `json:"createdAt" dc:"提交时间"`
+	UpdatedAt    *gtime.Time      `json:"updatedAt" dc:"更新时间"`
+}
+
+type ReprocessTaskReq struct {
+	g.Meta    `path:"/task/{request_id}/reprocess" method:"post" summary:"重新处理任务" dc:"需要 editor 及以上权限。用新的参数重新提交同一音频，结果保存为新版本。任务状态须为 success、partial_success 或 failed，且没有其他版本在处理中"`
+	RequestId string           `json:"request_id" v:"required" dc:"请求ID"`
+	Params    TaskSubmitParams `json:"Params" v:"required" dc:"任务参数"`
+	Activate  bool             `json:"activate" d:"true" dc:"处理成功后是否自动设为当前版本，默认 true；为 false 时需调用切换版本接口"`
+}
+type ReprocessTaskRes struct {
+	Version int    `json:"version" dc:"新版本号"`
+	Status  string `json:"status" dc:"处理状态"`
+}
+
+type GetTaskVersionListReq struct {
+	g.Meta    `path:"/task/{request_id}/versions" method:"get" summary:"获取结果版本列表"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+}
+type GetTaskVersionListRes struct {
+	Current  int           `json:"current" dc:"当前版本号，任务尚未提交时为 0"`
+	Versions []TaskVersion `json:"versions" dc:"版本列表，按版本号升序"`
+}
+
+type ActivateTaskVersionReq struct {
+	g.Meta    `path:"/task/{request_id}/versions/{version}/activate" method:"post" summary:"切换当前版本" dc:"需要 editor 及以上权限。只能切换到 success 或 partial_success 的版本。转写修正和说话人映射继续生效，新版本中不存在的句子的修正会被忽略"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+	Version   int    `json:"version" v:"required|min:1" dc:"版本号"`
+}
+type ActivateTaskVersionRes struct {
+	Version int    `json:"version" dc:"当前版本号"`
+	Status  string `json:"status" dc:"任务状态"`
+}
+
+// 截取音频片段，用于分享会议中的某一段
+type GetTaskClipReq struct {
+	g.Meta       `path:"/task/{request_id}/clip" method:"get" summary:"截取音频片段" dc:"按时间区间或句子范围截取原始音频，片段缓存在 TOS 中，同一区间只生成一次。默认返回片段的临时下载地址，download=true 时直接返回文件（Content-Disposition: attachment）"`
+	RequestId    string `json:"request_id" v:"required" dc:"请求ID"`
+	Start        int64  `json:"start" v:"min:0" dc:"开始时间（毫秒）"`
+	End          int64  `json:"end" v:"required-without:from_sentence|min:0" dc:"结束时间（毫秒），超过音频时长时截到结尾"`
+	FromSentence string `json:"from_sentence" dc:"起始句子ID。传入时按句子截取，忽略 start、end"`
+	ToSentence   string `json:"to_sentence" dc:"结束句子ID（包含），为空时只截取 from_sentence 一句"`
+	Format       string `json:"format" d:"mp3" v:"in:ogg,mp3,wav" dc:"音频格式：ogg、mp3（默认）、wav"`
+	Download     bool   `json:"download" d:"false" dc:"是否直接返回文件"`
+	Transcript   bool   `json:"transcript" d:"true" dc:"是否返回片段内的转写，已应用转写修正和说话人映射"`
+}
+type GetTaskClipRes struct {
+	Url        string               `json:"url" dc:"片段的临时下载地址"`
+	ExpiresAt  *gtime.Time          `json:"expiresAt" dc:"下载地址的过期时间"`
+	Name       string               `json:"name" dc:"文件名"`
+	Format     string               `json:"format" dc:"音频格式"`
+	Size       int64                `json:"size" dc:"文件大小（字节）"`
+	Start      int64                `json:"start" dc:"片段开始时间（毫秒）"`
+	End        int64                `json:"end" dc:"片段结束时间（毫秒）"`
+	Transcript []TranscriptSentence `json:"transcript,omitempty" dc:"片段内的句子，transcript=true 时返回"`
+}
+
+// 播放原始音频，由服务端代理 TOS，不暴露存储地址
+type GetTaskMediaReq struct {
+	g.Meta    `path:"/task/{request_id}/media" method:"get" mime:"application/octet-stream" summary:"播放原始音频" dc:"返回原始音频，支持 Range、If-Range 和 If-None-Match，播放器可以拖动进度。配置 media.proxy.redirect=true 时改为 302 跳转到 TOS 的预签名地址"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+}
+type GetTaskMediaRes struct{}
+
+// 获取音频波形，用于播放器绘制进度条
+type GetTaskWaveformReq struct {
+	g.Meta          `path:"/task/{request_id}/waveform" method:"get" mime:"application/json" summary:"获取波形数据" dc:"返回 audiowaveform 格式的单声道波形峰值（每个点一对最小值、最大值），可直接交给 peaks.js。响应体不经过统一的 JSON 包装。音频上传后自动生成，历史任务在第一次请求时生成"`
+	RequestId       string `json:"request_id" v:"required" dc:"请求ID"`
+	SamplesPerPixel int    `json:"samples_per_pixel" d:"256" v:"min:256|max:960000" dc:"每个点对应的采样数（采样率 16000），向下取整为 256 的倍数，越大越粗"`
+	PixelsPerSecond int    `json:"pixels_per_second" v:"min:0|max:62" dc:"每秒的点数，不为 0 时代替 samples_per_pixel"`
+	Format          string `json:"format" d:"json" v:"in:json,dat" dc:"json 或 dat（audiowaveform 二进制格式，版本 2）"`
+	Bits            int    `json:"bits" d:"16" v:"in:8,16" dc:"数据位数，8 时数据范围为 -128 ~ 127"`
+}
+type GetTaskWaveformRes struct{}
@@ -0,0 +1,38 @@
+package v2
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/model"
+	"doubao-speech-service/internal/model/lark"
+)
+
+// Task 在任务元数据之外返回类型化的结果，替代 v1 中的五个原始结果文件。
+type Task struct {
+	v1.TaskMeta
+	Revision int          `json:"revision" dc:"结果对应的转写修订号，0 表示上游原始结果"`
+	Result   *lark.Result `json:"result" dc:"类型化结果，已应用转写修正和说话人映射。任务未完成时为 null"`
+}
+
+type GetTaskReq struct {
+	g.Meta    `path:"/task/{request_id}" method:"get" summary:"获取任务详情（类型化结果）"`
+	RequestId string `json:"request_id" v:"required" dc:"请求ID"`
+	Revision  *int   `json:"revision" v:"min:0" dc:"转写修订号，为空时返回最新修订，0 返回上游原始结果"`
+}
+
+type GetTaskRes Task
+
+type SearchReq struct {
+	g.Meta      `path:"/search" method:"get" summary:"搜索任务（分页）" dc:"与 /transcription/search 相同，返回分页信息，按创建时间倒序"`
+	Keyword     string `json:"keyword" v:"required" dc:"关键词"`
+	WorkspaceId string `json:"workspace_id" dc:"团队空间ID，传入时在该空间内搜索，否则在个人空间内搜索"`
+	Limit       int    `json:"limit" d:"20" v:"min:1|max:100" dc:"返回条数，默认20，最大100"`
+	v1.PageReq
+	v1.TaskListFilter
+}
+
+type SearchRes struct {
+	model.PageInfo
+	TaskMetas []v1.SearchHit `json:"taskMetas" dc:"任务列表，按创建时间倒序"`
+}
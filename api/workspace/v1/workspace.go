@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/model"
+)
+
+type Workspace struct {
+	WorkspaceId string      `json:"workspaceId" dc:"团队空间ID"`
+	Name        string      `json:"name" dc:"名称"`
+	Description string      `json:"description" dc:"描述"`
+	CreatedBy   string      `json:"createdBy" dc:"创建人 UPN"`
+	Role        string      `json:"role" dc:"当前用户在该空间中的角色。owner / admin / editor / viewer"`
+	CreatedAt   *gtime.Time `json:"createdAt" dc:"创建时间"`
+}
+
+type Member struct {
+	Member    string      `json:"member" dc:"成员 UPN"`
+	Role      string      `json:"role" dc:"角色。owner：管理空间；admin：管理成员和任务；editor：上传、编辑任务；viewer：只读"`
+	CreatedAt *gtime.Time `json:"createdAt" dc:"加入时间"`
+}
+
+type CreateWorkspaceReq struct {
+	g.Meta      `path:"/create" method:"post" summary:"创建团队空间" dc:"创建人自动成为空间 owner"`
+	Name        string `json:"name" v:"required|max-length:100" dc:"名称"`
+	Description string `json:"description" v:"max-length:1000" dc:"描述"`
+}
+type CreateWorkspaceRes Workspace
+
+type GetWorkspaceListReq struct {
+	g.Meta `path:"/list" method:"get" summary:"获取我加入的团队空间" dc:"按创建时间倒序"`
+	Cursor string `json:"cursor" dc:"分页游标，为空表示第一页。使用上一次返回的 nextCursor / prevCursor 翻页"`
+	Limit  int    `json:"limit" d:"50" v:"min:1|max:100" dc:"本次请求返回的数据条数"`
+	Total  string `json:"total" d:"exact" v:"in:none,exact,estimate" dc:"是否返回总数。none：不返回；exact：精确总数；estimate：估算总数"`
+}
+type GetWorkspaceListRes struct {
+	model.PageInfo
+	Workspaces []Workspace `json:"workspaces" dc:"团队空间列表"`
+}
+
+type GetWorkspaceReq struct {
+	g.Meta      `path:"/{workspace_id}" method:"get" summary:"获取团队空间详情"`
+	WorkspaceId string `json:"workspace_id" v:"required" dc:"团队空间ID"`
+}
+type GetWorkspaceRes struct {
+	Workspace
+	Members []Member `json:"members" dc:"成员列表"`
+}
+
+type UpdateWorkspaceReq struct {
+	g.Meta      `path:"/{workspace_id}" method:"put" summary:"修改团队空间" dc:"需要 admin 及以上角色"`
+	WorkspaceId string `json:"workspace_id" v:"required" dc:"团队空间ID"`
+	Name        string `json:"name" v:"required|max-length:100" dc:"名称"`
+	Description string `json:"description" v:"max-length:1000" dc:"描述"`
+}
+type UpdateWorkspaceRes Workspace
+
+type DeleteWorkspaceReq struct {
+	g.Meta      `path:"/{workspace_id}" method:"delete" summary:"删除团队空间" dc:"需要 owner 角色。空间内的任务会移回各自拥有者的个人空间"`
+	WorkspaceId string `json:"workspace_id" v:"required" dc:"团队空间ID"`
+}
+type DeleteWorkspaceRes struct {
+	Success bool `json:"success" dc:"是否删除成功"`
+}
+
+// 添加成员。成员已存在时更新角色。
+type SetMemberReq struct {
+	g.Meta      `path:"/{workspace_id}/member" method:"post" summary:"添加或修改成员" dc:"需要 admin 及以上角色，只有 owner 可以授予 owner 角色"`
+	WorkspaceId string `json:"workspace_id" v:"required" dc:"团队空间ID"`
+	Member      string `json:"member" v:"required" dc:"成员 UPN"`
+	Role        string `json:"role" d:"viewer" v:"in:owner,admin,editor,viewer" dc:"角色"`
+}
+type SetMemberRes Member
+
+type RemoveMemberReq struct {
+	g.Meta      `path:"/{workspace_id}/member/{member}" method:"delete" summary:"移除成员" dc:"需要 admin 及以上角色；成员也可以移除自己（退出空间）"`
+	WorkspaceId string `json:"workspace_id" v:"required" dc:"团队空间ID"`
+	Member      string `json:"member" v:"required" dc:"成员 UPN"`
+}
+type RemoveMemberRes struct {
+	Success bool `json:"success" dc:"是否移除成功"`
+}
+
+// 会议纪要模板。模板是 Markdown 格式的 Go text/template，可用字段见 /transcription/task/{request_id}/minutes 接口说明
+type MinutesTemplate struct {
+	Name      string      `json:"name" dc:"模板名称"`
+	Content   string      `json:"content" dc:"模板内容"`
+	CreatedBy string      `json:"createdBy" dc:"创建人 UPN"`
+	UpdatedAt *gtime.Time `json:"updatedAt" dc:"修改时间"`
+	CreatedAt *gtime.Time `json:"createdAt" dc:"创建时间"`
+}
+
+// 保存纪要模板。同名模板已存在时覆盖。
+type SetMinutesTemplateReq struct {
+	g.Meta      `path:"/{workspace_id}/minutes-template" method:"post" summary:"保存纪要模板" dc:"需要 admin 及以上角色。保存前会解析模板并用示例数据试渲染"`
+	WorkspaceId string `json:"workspace_id" v:"required" dc:"团队空间ID"`
+	Name        string `json:"name" v:"required|max-length:50|not-in:default" dc:"模板名称，default 为保留名称"`
+	Content     string `json:"content" v:"required|max-length:65536" dc:"模板内容"`
+}
+type SetMinutesTemplateRes MinutesTemplate
+
+type GetMinutesTemplateListReq struct {
+	g.Meta      `path:"/{workspace_id}/minutes-template" method:"get" summary:"获取纪要模板列表"`
+	WorkspaceId string `json:"workspace_id" v:"required" dc:"团队空间ID"`
+}
+type GetMinutesTemplateListRes struct {
+	Default   string            `json:"default" dc:"默认模板内容，可作为自定义模板的起点"`
+	Templates []MinutesTemplate `json:"templates" dc:"空间内的自定义模板，按名称排序"`
+}
+
+type DeleteMinutesTemplateReq struct {
+	g.Meta      `path:"/{workspace_id}/minutes-template/{name}" method:"delete" summary:"删除纪要模板" dc:"需要 admin 及以上角色"`
+	WorkspaceId string `json:"workspace_id" v:"required" dc:"团队空间ID"`
+	Name        string `json:"name" v:"required" dc:"模板名称"`
+}
+type DeleteMinutesTemplateRes struct {
+	Success bool `json:"success" dc:"是否删除成功"`
+}
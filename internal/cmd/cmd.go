@@ -12,9 +12,12 @@ import (
 	"github.com/gogf/gf/v2/os/gcmd"
 	"github.com/gorilla/websocket"
 
+	"doubao-speech-service/internal/controller/todo"
 	"doubao-speech-service/internal/controller/transcription"
+	"doubao-speech-service/internal/controller/workspace"
 	"doubao-speech-service/internal/middlewares"
 	meetingRecordSvc "doubao-speech-service/internal/service/meetingRecord"
+	"doubao-speech-service/internal/service/pagination"
 	transcriptionSvc "doubao-speech-service/internal/service/transcription"
 )
 
@@ -35,10 +38,13 @@ var (
 			fmt.Println("Doubao Speech Microservice")
 			fmt.Println("Copyright 2025 The Chinese University of Hong Kong, Shenzhen")
 			fmt.Println()
+			if err = pagination.Init(ctx); err != nil {
+				return err
+			}
 			s := g.Server()
 			s.SetPort(g.Cfg().MustGet(ctx, "server.port").Int())
 			s.SetClientMaxBodySize(1024 * 1024 * 1024)
-			s.Use(middlewares.BrotliMiddleware)
+			s.Use(middlewares.CompressMiddleware)
 			s.Use(ghttp.MiddlewareCORS)
 			s = setupWebSocketHandler(s)
 			oai := s.GetOpenApi()
@@ -48,11 +54,29 @@ var (
 			s.SetSwaggerPath(g.Cfg().MustGet(ctx, "server.swaggerPath").String())
 
 			s.Group("/transcription", func(group *ghttp.RouterGroup) {
-				group.Middleware(ghttp.MiddlewareHandlerResponse)
+				group.Middleware(middlewares.ConditionalMiddleware, ghttp.MiddlewareHandlerResponse)
 				group.Bind(
 					transcription.NewV1(),
 				)
 			})
+			s.Group("/transcription/v2", func(group *ghttp.RouterGroup) {
+				group.Middleware(middlewares.ConditionalMiddleware, ghttp.MiddlewareHandlerResponse)
+				group.Bind(
+					transcription.NewV2(),
+				)
+			})
+			s.Group("/workspace", func(group *ghttp.RouterGroup) {
+				group.Middleware(ghttp.MiddlewareHandlerResponse)
+				group.Bind(
+					workspace.NewV1(),
+				)
+			})
+			s.Group("/todo", func(group *ghttp.RouterGroup) {
+				group.Middleware(ghttp.MiddlewareHandlerResponse)
+				group.Bind(
+					todo.NewV1(),
+				)
+			})
 
 			go transcriptionSvc.Recover(ctx)
 
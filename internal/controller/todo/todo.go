@@ -0,0 +1,75 @@
+package todo
+
+import (
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/todo/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/todo"
+)
+
+// todoRecord 是列表查询的一行：待办以及所属任务的标题和文件名。
+type todoRecord struct {
+	Id         int64       `orm:"id"`
+	TodoId     string      `orm:"todo_id"`
+	RequestId  string      `orm:"request_id"`
+	TaskTitle  string      `orm:"task_title"`
+	FileName   string      `orm:"file_name"`
+	Content    string      `orm:"content"`
+	Executor   string      `orm:"executor"`
+	Deadline   string      `orm:"deadline"`
+	SentenceId string      `orm:"sentence_id"`
+	StartTime  int64       `orm:"start_time"`
+	Assignee   string      `orm:"assignee"`
+	DueDate    *gtime.Time `orm:"due_date"`
+	Done       bool        `orm:"done"`
+	DoneAt     *gtime.Time `orm:"done_at"`
+	DoneBy     string      `orm:"done_by"`
+	UpdatedBy  string      `orm:"updated_by"`
+	UpdatedAt  *gtime.Time `orm:"updated_at"`
+	CreatedAt  *gtime.Time `orm:"created_at"`
+}
+
+func toTodo(r todoRecord) v1.Todo {
+	t := v1.Todo{
+		TodoId:     r.TodoId,
+		RequestId:  r.RequestId,
+		TaskTitle:  r.TaskTitle,
+		FileName:   r.FileName,
+		Content:    r.Content,
+		Executor:   r.Executor,
+		Deadline:   r.Deadline,
+		SentenceId: r.SentenceId,
+		Assignee:   r.Assignee,
+		Done:       r.Done,
+		DoneAt:     r.DoneAt,
+		DoneBy:     r.DoneBy,
+		UpdatedBy:  r.UpdatedBy,
+		UpdatedAt:  r.UpdatedAt,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.SentenceId != "" {
+		start := r.StartTime
+		t.StartTime = &start
+	}
+	if r.DueDate != nil {
+		t.DueDate = r.DueDate.Format("Y-m-d")
+	}
+	return t
+}
+
+func toFilter(f v1.TodoFilter, user access.User) todo.Filter {
+	filter := todo.Filter{
+		Status:      f.Status,
+		Assignee:    f.Assignee,
+		RequestId:   f.RequestId,
+		WorkspaceId: f.WorkspaceId,
+	}
+	if filter.Assignee == "me" {
+		filter.Assignee = user.ID
+	}
+	if f.DueBefore != "" {
+		filter.DueBefore = gtime.NewFromStr(f.DueBefore)
+	}
+	return filter
+}
@@ -0,0 +1,58 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "doubao-speech-service/api/todo/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/todo"
+)
+
+// maxIcsItems 是一次导出的最多待办数
+const maxIcsItems = 1000
+
+func (c *ControllerV1) ExportTodoIcs(ctx context.Context, req *v1.ExportTodoIcsReq) (res *v1.ExportTodoIcsRes, err error) {
+	user := access.CurrentUser(ctx)
+	filter := toFilter(req.TodoFilter, user)
+	filter.HasDue = true
+
+	cols := dao.Todo.Columns()
+	var rows []todoRecord
+	if err = todo.Query(ctx, user, filter).
+		Fields(todo.Fields()...).
+		Order("t."+cols.DueDate, "t."+cols.Id).
+		Limit(maxIcsItems).
+		Scan(&rows); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询待办失败")
+	}
+
+	items := make([]todo.Item, 0, len(rows))
+	for _, r := range rows {
+		title := r.TaskTitle
+		if title == "" {
+			title = r.FileName
+		}
+		items = append(items, todo.Item{
+			TodoId:    r.TodoId,
+			Content:   r.Content,
+			Executor:  r.Executor,
+			Assignee:  r.Assignee,
+			DueDate:   r.DueDate,
+			StartTime: r.StartTime,
+			HasTime:   r.SentenceId != "",
+			TaskTitle: title,
+		})
+	}
+
+	r := g.RequestFromCtx(ctx)
+	r.Response.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	r.Response.Header().Set("Content-Disposition", `attachment; filename="todos.ics"`)
+	r.Response.Write(todo.ICS(items, time.Now()))
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/todo/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/pagination"
+	"doubao-speech-service/internal/service/todo"
+)
+
+func (c *ControllerV1) GetTodoList(ctx context.Context, req *v1.GetTodoListReq) (res *v1.GetTodoListRes, err error) {
+	res = &v1.GetTodoListRes{Todos: []v1.Todo{}}
+	user := access.CurrentUser(ctx)
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	records, page, err := pagination.Query(ctx, todo.Query(ctx, user, toFilter(req.TodoFilter, user)), todo.Keyset(req.Sort), "id", pagination.Params{
+		Cursor: req.Cursor,
+		Limit:  limit,
+		Total:  req.Total,
+	}, todo.Fields()...)
+	if err != nil {
+		return nil, err
+	}
+	var rows []todoRecord
+	if err = records.Structs(&rows); err != nil {
+		return nil, gerror.Wrap(err, "解析待办列表失败")
+	}
+	for _, r := range rows {
+		res.Todos = append(res.Todos, toTodo(r))
+	}
+	res.PageInfo = page.Info()
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package todo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/todo/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/todo"
+)
+
+func (c *ControllerV1) UpdateTodo(ctx context.Context, req *v1.UpdateTodoReq) (res *v1.UpdateTodoRes, err error) {
+	user := access.CurrentUser(ctx)
+	record, err := todo.Get(ctx, req.TodoId)
+	if err != nil {
+		return nil, err
+	}
+	// 负责人本人可以标记完成，其余修改需要任务的 editor 权限
+	onlyDone := req.Assignee == nil && req.DueDate == nil
+	if !onlyDone || record.Assignee == "" || record.Assignee != user.ID {
+		if _, err = access.Require(ctx, record.RequestId, user, access.RoleEditor); err != nil {
+			return nil, err
+		}
+	}
+
+	cols := dao.Todo.Columns()
+	data := g.Map{
+		cols.UpdatedBy: user.ID,
+		cols.UpdatedAt: gtime.Now(),
+	}
+	if req.Done != nil && *req.Done != record.Done {
+		data[cols.Done] = *req.Done
+		if *req.Done {
+			data[cols.DoneAt] = gtime.Now()
+			data[cols.DoneBy] = user.ID
+		} else {
+			data[cols.DoneAt] = nil
+			data[cols.DoneBy] = ""
+		}
+	}
+	if req.Assignee != nil {
+		data[cols.Assignee] = strings.TrimSpace(*req.Assignee)
+	}
+	if req.DueDate != nil {
+		if due := strings.TrimSpace(*req.DueDate); due == "" {
+			data[cols.DueDate] = nil
+		} else if parsed, err := gtime.StrToTimeFormat(due, "Y-m-d"); err != nil {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "截止日期格式错误，应为 YYYY-MM-DD：%s", due)
+		} else {
+			data[cols.DueDate] = parsed.Format("Y-m-d")
+		}
+	}
+	if _, err = dao.Todo.Ctx(ctx).Data(data).Where(cols.TodoId+" = ?", req.TodoId).Update(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "修改待办失败")
+	}
+
+	var row todoRecord
+	if err = todo.Query(ctx, user, todo.Filter{Status: todo.StatusAll}).
+		Fields(todo.Fields()...).
+		Where("t."+cols.TodoId+" = ?", req.TodoId).
+		Scan(&row); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询待办失败")
+	}
+	out := v1.UpdateTodoRes(toTodo(row))
+	return &out, nil
+}
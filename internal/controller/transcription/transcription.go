@@ -3,3 +3,147 @@
 // =================================================================================
 
 package transcription
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/conditional"
+	"doubao-speech-service/internal/service/export"
+	"doubao-speech-service/internal/service/pagination"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+// toTaskFilter 把列表 / 搜索接口的筛选参数转换为 service 层的筛选条件。
+func toTaskFilter(f v1.TaskListFilter) transcription.TaskFilter {
+	return transcription.TaskFilter{
+		Status:      f.Status,
+		CreatedFrom: f.CreatedFrom,
+		CreatedTo:   f.CreatedTo,
+		FileType:    f.FileType,
+		DurationMin: f.DurationMin,
+		DurationMax: f.DurationMax,
+		Features:    f.Features,
+		Folder:      f.Folder,
+		Tags:        f.Tags,
+	}
+}
+
+// toPageParams 把列表接口的分页参数转换为 pagination.Params。
+func toPageParams(p v1.PageReq, limit int) pagination.Params {
+	total := p.Total
+	if total == "" {
+		total = pagination.TotalExact
+	}
+	return pagination.Params{
+		Cursor: p.Cursor,
+		Limit:  limit,
+		Total:  total,
+	}
+}
+
+// toExportMeta 从任务记录中取出导出文件需要的元数据。
+func toExportMeta(record *entity.Transcription) export.Meta {
+	return export.Meta{
+		RequestId: record.RequestId,
+		Title:     record.Title,
+		FileName:  record.FileInfo.Get("filename").String(),
+		CreatedAt: record.CreatedAt,
+	}
+}
+
+// notModified 给响应设置任务的 ETag。请求的 If-None-Match 与之匹配时返回 true，处理函数直接返回即可，
+// 由 ConditionalMiddleware 输出 304。
+func notModified(ctx context.Context, requestId string) (bool, error) {
+	tag, err := transcription.ETag(ctx, requestId, g.RequestFromCtx(ctx).URL.RequestURI())
+	if err != nil {
+		return false, err
+	}
+	return conditional.Check(ctx, tag), nil
+}
+
+// listNotModified 给列表响应设置 ETag，与 If-None-Match 匹配时返回 true。
+func listNotModified(ctx context.Context, records gdb.Result, page *pagination.Page) bool {
+	uri := g.RequestFromCtx(ctx).URL.RequestURI()
+	if page == nil {
+		return conditional.Check(ctx, transcription.ListETag(records, uri))
+	}
+	return conditional.Check(ctx, transcription.ListETag(records, uri, page.HasMore, page.Total, page.TotalEstimated))
+}
+
+// writeFile 把导出文件作为附件直接写入响应，MiddlewareHandlerResponse 检测到已有输出后不会再包装 JSON。
+func writeFile(ctx context.Context, file *export.File) {
+	r := g.RequestFromCtx(ctx)
+	r.Response.Header().Set("Content-Type", file.ContentType)
+	r.Response.Header().Set("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(file.Name))
+	r.Response.Write(file.Data)
+}
+
+func toTaskSpeakers(infos []transcription.SpeakerInfo) []v1.TaskSpeaker {
+	speakers := make([]v1.TaskSpeaker, 0, len(infos))
+	for _, s := range infos {
+		speakers = append(speakers, v1.TaskSpeaker{
+			Id:           s.Id,
+			OriginalName: s.OriginalName,
+			Name:         s.Name,
+			Profile:      s.Profile,
+			MergeInto:    s.MergeInto,
+			Utterances:   s.Utterances,
+		})
+	}
+	return speakers
+}
+
+func toLarkCorrections(corrections []v1.TranscriptCorrection) []lark.Correction {
+	out := make([]lark.Correction, 0, len(corrections))
+	for _, c := range corrections {
+		out = append(out, lark.Correction{
+			SentenceId: c.SentenceId,
+			Content:    c.Content,
+			SpeakerId:  c.SpeakerId,
+			StartTime:  c.StartTime,
+			EndTime:    c.EndTime,
+		})
+	}
+	return out
+}
+
+func toTranscriptCorrections(corrections []lark.Correction) []v1.TranscriptCorrection {
+	out := make([]v1.TranscriptCorrection, 0, len(corrections))
+	for _, c := range corrections {
+		out = append(out, v1.TranscriptCorrection{
+			SentenceId: c.SentenceId,
+			Content:    c.Content,
+			SpeakerId:  c.SpeakerId,
+			StartTime:  c.StartTime,
+			EndTime:    c.EndTime,
+		})
+	}
+	return out
+}
+
+func toTranscriptSentence(s transcription.Sentence) v1.TranscriptSentence {
+	return v1.TranscriptSentence{
+		SentenceId:  s.SentenceId,
+		SpeakerId:   s.SpeakerId,
+		Speaker:     s.Speaker,
+		Content:     s.Content,
+		Translation: s.Translation,
+		StartTime:   s.StartTime,
+		EndTime:     s.EndTime,
+	}
+}
+
+func toTranscriptSentences(sentences []transcription.Sentence) []v1.TranscriptSentence {
+	out := make([]v1.TranscriptSentence, 0, len(sentences))
+	for _, s := range sentences {
+		out = append(out, toTranscriptSentence(s))
+	}
+	return out
+}
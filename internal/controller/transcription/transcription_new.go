@@ -13,3 +13,9 @@ type ControllerV1 struct{}
 func NewV1() transcription.ITranscriptionV1 {
 	return &ControllerV1{}
 }
+
+type ControllerV2 struct{}
+
+func NewV2() transcription.ITranscriptionV2 {
+	return &ControllerV2{}
+}
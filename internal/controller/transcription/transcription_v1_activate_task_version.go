@@ -0,0 +1,23 @@
+package transcription
+
+import (
+	"context"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) ActivateTaskVersion(ctx context.Context, req *v1.ActivateTaskVersionReq) (res *v1.ActivateTaskVersionRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleEditor); err != nil {
+		return nil, err
+	}
+	if err = transcription.Activate(ctx, req.RequestId, req.Version); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ActivateTaskVersionRes{Version: req.Version, Status: record.Status}, nil
+}
@@ -0,0 +1,58 @@
+package transcription
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/share"
+)
+
+func (c *ControllerV1) CreateShareLink(ctx context.Context, req *v1.CreateShareLinkReq) (res *v1.CreateShareLinkRes, err error) {
+	user := access.CurrentUser(ctx)
+	if _, err = access.Require(ctx, req.RequestId, user, access.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	token, err := share.NewLinkToken()
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := share.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	var expiresAt *gtime.Time
+	if req.ExpiresIn > 0 {
+		expiresAt = gtime.Now().Add(time.Duration(req.ExpiresIn) * time.Second)
+	}
+
+	cols := dao.TranscriptionShareLink.Columns()
+	if _, err = dao.TranscriptionShareLink.Ctx(ctx).Data(g.Map{
+		cols.Token:        token,
+		cols.RequestId:    req.RequestId,
+		cols.PasswordHash: passwordHash,
+		cols.ExpiresAt:    expiresAt,
+		cols.CreatedBy:    user.ID,
+	}).Insert(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "创建分享链接失败")
+	}
+
+	var link entity.TranscriptionShareLink
+	if err = dao.TranscriptionShareLink.Ctx(ctx).
+		Where(cols.Token+" = ?", token).
+		Limit(1).
+		Scan(&link); err != nil {
+		return nil, gerror.Wrap(err, "查询分享链接失败")
+	}
+	shareLink := toShareLink(link)
+	return (*v1.CreateShareLinkRes)(&shareLink), nil
+}
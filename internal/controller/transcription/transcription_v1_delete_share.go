@@ -0,0 +1,33 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) DeleteShare(ctx context.Context, req *v1.DeleteShareReq) (res *v1.DeleteShareRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	cols := dao.TranscriptionShare.Columns()
+	sqlRes, err := dao.TranscriptionShare.Ctx(ctx).
+		Where(cols.Id+" = ?", req.ShareId).
+		Where(cols.RequestId+" = ?", req.RequestId).
+		Delete()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "取消共享失败")
+	}
+	if affected, err := sqlRes.RowsAffected(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "检查取消共享情况失败")
+	} else if affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "找不到共享记录")
+	}
+	return &v1.DeleteShareRes{Success: true}, nil
+}
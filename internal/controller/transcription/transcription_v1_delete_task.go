@@ -5,19 +5,55 @@ import (
 
 	v1 "doubao-speech-service/api/transcription/v1"
 	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
 
+	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 func (c *ControllerV1) DeleteTask(ctx context.Context, req *v1.DeleteTaskReq) (res *v1.DeleteTaskRes, err error) {
 	res = &v1.DeleteTaskRes{}
-	if sqlRes, err := dao.Transcription.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
-		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "删除任务失败")
-	} else if eftRow, err := sqlRes.RowsAffected(); eftRow == 0 {
-		return nil, gerror.New("找不到任务。数据库影响行数为0。")
-	} else if err != nil {
-		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "检查任务删除情况失败")
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleOwner); err != nil {
+		return nil, err
+	}
+	err = dao.Transcription.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if sqlRes, err := dao.Transcription.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除任务失败")
+		} else if eftRow, err := sqlRes.RowsAffected(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "检查任务删除情况失败")
+		} else if eftRow == 0 {
+			return gerror.NewCode(gcode.CodeNotFound, "任务不存在")
+		}
+		// 一并清理共享记录、分享链接、说话人映射、转写修订、检索文本、待办、结果版本和结果文件
+		if _, err := dao.TranscriptionShare.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除共享记录失败")
+		}
+		if _, err := dao.TranscriptionShareLink.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除分享链接失败")
+		}
+		if _, err := dao.TranscriptionSpeaker.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除说话人映射失败")
+		}
+		if _, err := dao.TranscriptionRevision.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除转写修订失败")
+		}
+		if _, err := dao.TranscriptionText.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除检索文本失败")
+		}
+		if _, err := dao.Todo.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除待办失败")
+		}
+		if _, err := dao.TranscriptionVersion.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除结果版本失败")
+		}
+		if _, err := dao.TranscriptionResult.Ctx(ctx).Where("request_id = ?", req.RequestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除结果文件失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	res.Success = true
 	return
@@ -0,0 +1,36 @@
+package transcription
+
+import (
+	"context"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/export"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) ExportTask(ctx context.Context, req *v1.ExportTaskReq) (res *v1.ExportTaskRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	if hit, err := notModified(ctx, req.RequestId); err != nil || hit {
+		return nil, err
+	}
+	record, result, err := transcription.LoadResult(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := export.Transcript(req.Format, toExportMeta(record), result, export.Options{
+		Mode:          req.Mode,
+		SpeakerLabels: req.SpeakerLabels,
+		Timestamps:    req.Timestamps,
+		MaxLineLength: req.MaxLineLength,
+		MergeSpeaker:  req.MergeSpeaker,
+	})
+	if err != nil {
+		return nil, err
+	}
+	writeFile(ctx, file)
+	return nil, nil
+}
@@ -0,0 +1,84 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/analytics"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetAnalytics(ctx context.Context, req *v1.GetAnalyticsReq) (res *v1.GetAnalyticsRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := transcription.Analytics(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, "任务尚未完成，暂无对话统计")
+	}
+	return toAnalyticsRes(stats), nil
+}
+
+func toAnalyticsRes(a *analytics.Analytics) *v1.GetAnalyticsRes {
+	res := &v1.GetAnalyticsRes{
+		Duration:            a.Duration,
+		TalkTime:            a.TalkTime,
+		SilenceTime:         a.SilenceTime,
+		SilenceRatio:        a.SilenceRatio,
+		Utterances:          a.Utterances,
+		Turns:               a.Turns,
+		Interruptions:       a.Interruptions,
+		Characters:          a.Characters,
+		Words:               a.Words,
+		CharactersPerMinute: a.CharactersPerMinute,
+		WordsPerMinute:      a.WordsPerMinute,
+		Speakers:            make([]v1.AnalyticsSpeaker, 0, len(a.Speakers)),
+		Timeline:            make([]v1.AnalyticsTurn, 0, len(a.Timeline)),
+	}
+	if a.LongestMonologue != nil {
+		turn := toAnalyticsTurn(*a.LongestMonologue)
+		res.LongestMonologue = &turn
+	}
+	for _, s := range a.Speakers {
+		res.Speakers = append(res.Speakers, v1.AnalyticsSpeaker{
+			SpeakerId:           s.SpeakerId,
+			Speaker:             s.Speaker,
+			TalkTime:            s.TalkTime,
+			Share:               s.Share,
+			Utterances:          s.Utterances,
+			Turns:               s.Turns,
+			Characters:          s.Characters,
+			Words:               s.Words,
+			CharactersPerMinute: s.CharactersPerMinute,
+			WordsPerMinute:      s.WordsPerMinute,
+			LongestMonologue:    s.LongestMonologue,
+			Interruptions:       s.Interruptions,
+			Interrupted:         s.Interrupted,
+		})
+	}
+	for _, t := range a.Timeline {
+		res.Timeline = append(res.Timeline, toAnalyticsTurn(t))
+	}
+	return res
+}
+
+func toAnalyticsTurn(t analytics.Turn) v1.AnalyticsTurn {
+	return v1.AnalyticsTurn{
+		SpeakerId:  t.SpeakerId,
+		Speaker:    t.Speaker,
+		StartTime:  t.StartTime,
+		EndTime:    t.EndTime,
+		Utterances: t.Utterances,
+	}
+}
@@ -0,0 +1,45 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/minutes"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetMinutes(ctx context.Context, req *v1.GetMinutesReq) (res *v1.GetMinutesRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	record, result, err := transcription.LoadResult(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, "任务尚未完成，无法生成纪要")
+	}
+
+	// 自定义模板保存在任务所在的团队空间中
+	var content string
+	if req.Template != "" && req.Template != "default" {
+		if record.WorkspaceId == "" {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, "个人空间的任务只能使用默认模板")
+		}
+		if content, err = minutes.LoadTemplate(ctx, record.WorkspaceId, req.Template); err != nil {
+			return nil, err
+		}
+	}
+
+	meta := toExportMeta(record)
+	file, err := minutes.Render(req.Format, meta, minutes.NewData(meta, record.Duration, result), content)
+	if err != nil {
+		return nil, err
+	}
+	writeFile(ctx, file)
+	return nil, nil
+}
@@ -0,0 +1,41 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetRevisionList(ctx context.Context, req *v1.GetRevisionListReq) (res *v1.GetRevisionListRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	records, err := transcription.Revisions(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetRevisionListRes{Revisions: make([]v1.TranscriptRevision, 0, len(records))}
+	for _, r := range records {
+		var corrections []lark.Correction
+		if err = r.Corrections.Scan(&corrections); err != nil {
+			return nil, gerror.Wrap(err, "解析修订内容失败")
+		}
+		res.Revisions = append(res.Revisions, v1.TranscriptRevision{
+			Revision:    r.Revision,
+			Author:      r.Author,
+			Comment:     r.Comment,
+			Corrections: toTranscriptCorrections(corrections),
+			CreatedAt:   r.CreatedAt,
+		})
+	}
+	if len(records) > 0 {
+		res.Latest = records[0].Revision
+	}
+	return res, nil
+}
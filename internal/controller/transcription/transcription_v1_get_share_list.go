@@ -0,0 +1,55 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) GetShareList(ctx context.Context, req *v1.GetShareListReq) (res *v1.GetShareListRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetShareListRes{
+		Shares: []v1.Share{},
+		Links:  []v1.ShareLink{},
+	}
+	shareCols := dao.TranscriptionShare.Columns()
+	if err = dao.TranscriptionShare.Ctx(ctx).
+		Where(shareCols.RequestId+" = ?", req.RequestId).
+		OrderAsc(shareCols.Id).
+		Scan(&res.Shares); err != nil {
+		return nil, gerror.Wrap(err, "查询共享记录失败")
+	}
+
+	var links []entity.TranscriptionShareLink
+	linkCols := dao.TranscriptionShareLink.Columns()
+	if err = dao.TranscriptionShareLink.Ctx(ctx).
+		Where(linkCols.RequestId+" = ?", req.RequestId).
+		OrderDesc(linkCols.Id).
+		Scan(&links); err != nil {
+		return nil, gerror.Wrap(err, "查询分享链接失败")
+	}
+	for _, link := range links {
+		res.Links = append(res.Links, toShareLink(link))
+	}
+	return res, nil
+}
+
+func toShareLink(link entity.TranscriptionShareLink) v1.ShareLink {
+	return v1.ShareLink{
+		Token:       link.Token,
+		RequestId:   link.RequestId,
+		HasPassword: link.PasswordHash != "",
+		ExpiresAt:   link.ExpiresAt,
+		RevokedAt:   link.RevokedAt,
+		CreatedBy:   link.CreatedBy,
+		CreatedAt:   link.CreatedAt,
+	}
+}
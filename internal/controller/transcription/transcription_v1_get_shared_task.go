@@ -0,0 +1,73 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/service/share"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetSharedTask(ctx context.Context, req *v1.GetSharedTaskReq) (res *v1.GetSharedTaskRes, err error) {
+	password := req.Password
+	if password == "" {
+		password = g.RequestFromCtx(ctx).Header.Get("X-Share-Password")
+	}
+	link, err := share.ResolveLink(ctx, req.Token, password)
+	if err != nil {
+		return nil, err
+	}
+
+	// 只读取分享链接允许公开的字段
+	var record *entity.Transcription
+	cols := dao.Transcription.Columns()
+	if err = dao.Transcription.Ctx(ctx).
+		Fields(
+			cols.RequestId, cols.FileInfo, cols.Status, cols.CreatedAt, cols.Version,
+		).
+		Where(cols.RequestId+" = ?", link.RequestId).
+		Limit(1).
+		Scan(&record); err != nil {
+		return nil, gerror.Wrap(err, "获取任务记录失败")
+	}
+	if record == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "分享的任务已被删除")
+	}
+
+	files, err := transcription.ResultFiles(ctx, record,
+		transcription.KindAudioTranscription, transcription.KindChapter, transcription.KindSummarization,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// 与任务详情一样应用转写修正和说话人映射
+	_, corrections, err := transcription.Corrections(ctx, record.RequestId, transcription.LatestRevision)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := transcription.SpeakerProfiles(ctx, record.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript := transcription.ApplySpeakersToRaw(
+		transcription.ApplyCorrectionsToRaw(files.AudioTranscription, corrections), profiles,
+	)
+
+	return &v1.GetSharedTaskRes{
+		RequestId:              record.RequestId,
+		FileName:               record.FileInfo.Get("filename").String(),
+		Status:                 record.Status,
+		CreatedAt:              record.CreatedAt,
+		ExpiresAt:              link.ExpiresAt,
+		AudioTranscriptionFile: transcript,
+		ChapterFile:            files.Chapter,
+		SummarizationFile:      files.Summarization,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package transcription
+
+import (
+	"context"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetSpeakers(ctx context.Context, req *v1.GetSpeakersReq) (res *v1.GetSpeakersRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := transcription.Speakers(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.GetSpeakersRes{Speakers: toTaskSpeakers(infos)}, nil
+}
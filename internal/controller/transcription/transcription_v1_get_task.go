@@ -3,17 +3,115 @@ package transcription
 import (
 	"context"
 
+	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 
 	v1 "doubao-speech-service/api/transcription/v1"
 	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
 )
 
 func (c *ControllerV1) GetTask(ctx context.Context, req *v1.GetTaskReq) (res *v1.GetTaskRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	if hit, err := notModified(ctx, req.RequestId); err != nil || hit {
+		return nil, err
+	}
+	parts, err := transcription.SelectParts(req.Fields, req.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	// 只读取选中的部分，不读取类型化结果等大字段
+	cols := dao.Transcription.Columns()
+	fields := []any{cols.RequestId, cols.Version}
+	if parts[transcription.PartMeta] {
+		fields = append(fields, transcription.MetaFields()...)
+	}
+	row, err := dao.Transcription.Ctx(ctx).
+		Fields(fields...).
+		Where("request_id = ?", req.RequestId).
+		One()
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取任务记录失败")
+	}
+	if row.IsEmpty() {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "任务不存在")
+	}
+	var record *entity.Transcription
 	res = &v1.GetTaskRes{}
-	err = dao.Transcription.Ctx(ctx).Where("request_id = ?", req.RequestId).Scan(&res)
+	if err = row.Struct(&record); err != nil {
+		return nil, gerror.Wrap(err, "解析任务记录失败")
+	}
+	if err = row.Struct(&res.TaskMeta); err != nil {
+		return nil, gerror.Wrap(err, "解析任务记录失败")
+	}
+
+	// 转写修正和说话人映射只在返回时应用，数据库中的上游原始结果不变
+	var corrections []lark.Correction
+	if parts[transcription.PartTranscription] {
+		if _, corrections, err = transcription.Corrections(ctx, req.RequestId, transcription.LatestRevision); err != nil {
+			return nil, err
+		}
+	}
+
+	// 分页时只截取需要的句子（已应用修正），其余结果文件按选中的部分读取
+	var (
+		page      *transcription.SentencePage
+		sentences = transcription.SentenceRange{
+			Offset:    req.Offset,
+			Limit:     req.Limit,
+			StartTime: req.StartTime,
+			EndTime:   req.EndTime,
+		}
+	)
+	if parts[transcription.PartTranscription] && !sentences.IsZero() {
+		if page, err = transcription.TranscriptionPage(ctx, record, sentences, corrections); err != nil {
+			return nil, err
+		}
+	}
+	var files lark.Files
+	if page != nil {
+		files.AudioTranscription = page.Content
+		res.AudioTranscriptionPage = &v1.SentencePage{
+			Offset:  req.Offset,
+			Total:   page.Total,
+			HasMore: req.Limit > 0 && req.Offset+req.Limit < page.Total,
+		}
+		if kinds := parts.Kinds(transcription.KindAudioTranscription); len(kinds) > 0 {
+			rest, err := transcription.ResultFiles(ctx, record, kinds...)
+			if err != nil {
+				return nil, err
+			}
+			rest.AudioTranscription = files.AudioTranscription
+			files = rest
+		}
+	} else if kinds := parts.Kinds(); len(kinds) > 0 {
+		if files, err = transcription.ResultFiles(ctx, record, kinds...); err != nil {
+			return nil, err
+		}
+	}
+	res.AudioTranscriptionFile = files.AudioTranscription
+	res.ChapterFile = files.Chapter
+	res.InformationExtractionFile = files.InformationExtraction
+	res.SummarizationFile = files.Summarization
+	res.TranslationFile = files.Translation
+
+	if res.AudioTranscriptionFile == nil && res.TranslationFile == nil {
+		return res, nil
+	}
+	if page == nil {
+		res.AudioTranscriptionFile = transcription.ApplyCorrectionsToRaw(res.AudioTranscriptionFile, corrections)
+	}
+	profiles, err := transcription.SpeakerProfiles(ctx, req.RequestId)
 	if err != nil {
-		err = gerror.Wrap(err, "获取任务记录失败")
+		return nil, err
 	}
-	return
+	res.AudioTranscriptionFile = transcription.ApplySpeakersToRaw(res.AudioTranscriptionFile, profiles)
+	res.TranslationFile = transcription.ApplySpeakersToRaw(res.TranslationFile, profiles)
+	return res, nil
 }
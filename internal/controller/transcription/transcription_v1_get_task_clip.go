@@ -0,0 +1,79 @@
+package transcription
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetTaskClip(ctx context.Context, req *v1.GetTaskClipReq) (res *v1.GetTaskClipRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	var result *lark.Result
+	if req.FromSentence != "" || req.Transcript {
+		if result, err = transcription.Result(ctx, record); err != nil {
+			return nil, err
+		}
+	}
+	clip, err := transcription.MakeClip(ctx, record, result, transcription.ClipOptions{
+		Start:        req.Start,
+		End:          req.End,
+		FromSentence: req.FromSentence,
+		ToSentence:   req.ToSentence,
+		Format:       req.Format,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Download {
+		body, err := transcription.OpenClip(ctx, clip)
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		// 直接写到底层连接，不经过响应缓冲区
+		r := g.RequestFromCtx(ctx)
+		r.Response.Header().Set("Content-Type", clip.ContentType)
+		r.Response.Header().Set("Content-Length", strconv.FormatInt(clip.Size, 10))
+		r.Response.Header().Set("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(clip.Name))
+		r.Response.Writer.WriteHeader(http.StatusOK)
+		if _, err = io.Copy(r.Response.Writer, body); err != nil {
+			g.Log().Warningf(ctx, "[%s] 输出音频片段中断：%v", req.RequestId, err)
+		}
+		return nil, nil
+	}
+
+	link, expiresAt, err := transcription.ClipURL(ctx, clip)
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.GetTaskClipRes{
+		Url:       link,
+		ExpiresAt: gtime.New(expiresAt),
+		Name:      clip.Name,
+		Format:    req.Format,
+		Size:      clip.Size,
+		Start:     clip.Start,
+		End:       clip.End,
+	}
+	if req.Transcript {
+		res.Transcript = toTranscriptSentences(clip.Sentences)
+	}
+	return res, nil
+}
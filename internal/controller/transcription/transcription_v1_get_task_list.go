@@ -2,19 +2,20 @@ package transcription
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/errors/gerror"
-	"github.com/gogf/gf/v2/frame/g"
-	"github.com/gogf/gf/v2/os/gtime"
 
 	v1 "doubao-speech-service/api/transcription/v1"
 	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/pagination"
+	"doubao-speech-service/internal/service/transcription"
 )
 
 func (c *ControllerV1) GetTaskList(ctx context.Context, req *v1.GetTaskListReq) (res *v1.GetTaskListRes, err error) {
 	res = &v1.GetTaskListRes{}
-	userID := g.RequestFromCtx(ctx).Header.Get("X-User-ID")
+	user := access.CurrentUser(ctx)
 
 	limit := req.Limit
 	if limit <= 0 {
@@ -26,43 +27,59 @@ func (c *ControllerV1) GetTaskList(ctx context.Context, req *v1.GetTaskListReq)
 
 	cols := dao.Transcription.Columns()
 
-	model := dao.Transcription.Ctx(ctx).
-		Where(cols.Owner+" = ?", userID)
+	if req.WorkspaceId != "" {
+		if _, err = access.RequireWorkspace(ctx, req.WorkspaceId, user, access.WorkspaceRoleViewer); err != nil {
+			return nil, err
+		}
+	}
 
-	if req.LastRequestID != "" && req.LastRequestID != "0" {
-		var anchor struct {
-			Id        int64       `json:"id"`
-			CreatedAt *gtime.Time `json:"created_at"`
+	// workspace_id：团队空间内的任务；mine：自己个人空间的任务；shared：别人共享给我（或我所在用户组）的任务
+	scope := func(m *gdb.Model) *gdb.Model {
+		switch {
+		case req.WorkspaceId != "":
+			return m.Where(cols.WorkspaceId+" = ?", req.WorkspaceId)
+		case req.View == "shared":
+			return m.
+				Where(cols.RequestId+" IN ?", access.SharedRequestIds(ctx, user)).
+				WhereNot(cols.Owner, user.ID)
+		default:
+			return m.Where(cols.Owner+" = ?", user.ID).WhereNull(cols.WorkspaceId)
 		}
+	}
+
+	filter := toTaskFilter(req.TaskListFilter)
+	keyset := transcription.TaskSort{
+		By:   req.SortBy,
+		Desc: req.Order != "asc",
+	}.Keyset()
+	params := toPageParams(req.PageReq, limit)
 
-		if err = dao.Transcription.Ctx(ctx).
-			Fields(cols.Id, cols.CreatedAt).
-			Where(cols.Owner+" = ?", userID).
+	// 兼容旧的 last_request_id 参数：把锚点记录转换为游标
+	if params.Cursor == "" && req.LastRequestID != "" && req.LastRequestID != "0" {
+		anchor, err := dao.Transcription.Ctx(ctx).
+			Handler(scope).
+			Fields(cols.Id, keyset.SortKeyField()).
 			Where(cols.RequestId+" = ?", req.LastRequestID).
-			Limit(1).
-			Scan(&anchor); err != nil {
+			One()
+		if err != nil {
 			return nil, gerror.Wrap(err, "查询数据库失败")
 		}
-
-		if anchor.Id == 0 || anchor.CreatedAt == nil {
+		if anchor.IsEmpty() {
 			return nil, gerror.New("last_request_id不存在或无效")
 		}
-
-		model = model.Where(
-			fmt.Sprintf("(%s < ?) OR (%s = ? AND %s < ?)", cols.CreatedAt, cols.CreatedAt, cols.Id),
-			anchor.CreatedAt, anchor.CreatedAt, anchor.Id,
-		)
+		params.Cursor = keyset.Cursor(ctx, anchor, cols.Id)
 	}
 
-	if err = model.
-		OrderDesc(cols.CreatedAt).
-		OrderDesc(cols.Id).
-		Limit(limit).
-		Scan(&res.TaskMetas); err != nil {
-		return nil, gerror.Wrap(err, "查询数据库失败")
+	records, page, err := pagination.Query(ctx, dao.Transcription.Ctx(ctx).Handler(scope, filter.Apply), keyset, cols.Id, params, transcription.MetaFields()...)
+	if err != nil {
+		return nil, err
+	}
+	if listNotModified(ctx, records, page) {
+		return nil, nil
 	}
-	if res.Total, err = model.Count(); err != nil {
-		return nil, gerror.Wrap(err, "统计总记录数失败")
+	if err = records.Structs(&res.TaskMetas); err != nil {
+		return nil, gerror.Wrap(err, "解析任务列表失败")
 	}
+	res.PageInfo = page.Info()
 	return res, nil
 }
@@ -0,0 +1,46 @@
+package transcription
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetTaskMedia(ctx context.Context, req *v1.GetTaskMediaReq) (res *v1.GetTaskMediaRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	r := g.RequestFromCtx(ctx)
+	link, err := transcription.MediaRedirectURL(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if link != "" {
+		http.Redirect(r.Response.Writer, r.Request, link, http.StatusFound)
+		return nil, nil
+	}
+
+	media, err := transcription.OpenMedia(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	defer media.Reader.Close()
+	// 直接写到底层连接。ServeContent 处理 Range、If-Range、If-None-Match 等条件请求
+	header := r.Response.Header()
+	if media.ContentType != "" {
+		header.Set("Content-Type", media.ContentType)
+	}
+	header.Set("ETag", media.Info.ETag)
+	header.Set("Cache-Control", "private, no-cache")
+	http.ServeContent(r.Response.Writer, r.Request, media.Name, media.Info.LastModified, media.Reader)
+	return nil, nil
+}
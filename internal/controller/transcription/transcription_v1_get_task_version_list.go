@@ -0,0 +1,52 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetTaskVersionList(ctx context.Context, req *v1.GetTaskVersionListReq) (res *v1.GetTaskVersionListRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := transcription.Versions(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetTaskVersionListRes{Versions: make([]v1.TaskVersion, 0, len(versions))}
+	for _, v := range versions {
+		var params transcription.SubmitRequest
+		if v.TaskParams != nil {
+			if err = v.TaskParams.Scan(&params); err != nil {
+				return nil, gerror.Wrap(err, "解析任务参数失败")
+			}
+		}
+		current := v.TaskId == record.TaskId
+		if current {
+			res.Current = v.Version
+		}
+		res.Versions = append(res.Versions, v1.TaskVersion{
+			Version:      v.Version,
+			Current:      current,
+			Status:       v.Status,
+			Params:       params.Params,
+			FetchErrors:  v.FetchErrors,
+			Duration:     v.Duration,
+			AutoActivate: v.AutoActivate,
+			CreatedBy:    v.CreatedBy,
+			CreatedAt:    v.CreatedAt,
+			UpdatedAt:    v.UpdatedAt,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,50 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/conditional"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) GetTaskWaveform(ctx context.Context, req *v1.GetTaskWaveformReq) (res *v1.GetTaskWaveformRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	factor := req.SamplesPerPixel / transcription.WaveformSamplesPerPixel
+	if req.PixelsPerSecond > 0 {
+		factor = transcription.WaveformSampleRate / transcription.WaveformSamplesPerPixel / req.PixelsPerSecond
+	}
+	factor = max(factor, 1)
+	// 原始音频上传后不会改变，波形只取决于请求参数
+	if conditional.Check(ctx, conditional.Tag(req.RequestId, "waveform", factor, req.Format, req.Bits)) {
+		return nil, nil
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	waveform, err := transcription.Waveform(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	waveform = waveform.Resample(factor)
+
+	r := g.RequestFromCtx(ctx)
+	if req.Format == "dat" {
+		r.Response.Header().Set("Content-Type", "application/octet-stream")
+		r.Response.Write(waveform.Binary(req.Bits))
+		return nil, nil
+	}
+	data, err := waveform.JSON(req.Bits)
+	if err != nil {
+		return nil, err
+	}
+	r.Response.Header().Set("Content-Type", "application/json")
+	r.Response.Write(data)
+	return nil, nil
+}
@@ -0,0 +1,38 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) MoveTask(ctx context.Context, req *v1.MoveTaskReq) (res *v1.MoveTaskRes, err error) {
+	user := access.CurrentUser(ctx)
+	if _, err = access.Require(ctx, req.RequestId, user, access.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	// 为空表示移回拥有者的个人空间
+	var workspaceID any
+	if req.WorkspaceId != "" {
+		if _, err = access.RequireWorkspace(ctx, req.WorkspaceId, user, access.WorkspaceRoleEditor); err != nil {
+			return nil, err
+		}
+		workspaceID = req.WorkspaceId
+	}
+
+	cols := dao.Transcription.Columns()
+	if _, err = dao.Transcription.Ctx(ctx).
+		Data(g.Map{cols.WorkspaceId: workspaceID}).
+		Where(cols.RequestId+" = ?", req.RequestId).
+		Update(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "移动任务失败")
+	}
+	return &v1.MoveTaskRes{Success: true}, nil
+}
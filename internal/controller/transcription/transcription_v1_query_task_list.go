@@ -4,25 +4,35 @@ import (
 	"context"
 
 	"github.com/gogf/gf/v2/errors/gerror"
-	"github.com/gogf/gf/v2/frame/g"
 
 	v1 "doubao-speech-service/api/transcription/v1"
 	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
 )
 
 func (c *ControllerV1) QueryTaskList(ctx context.Context, req *v1.QueryTaskListReq) (res *v1.QueryTaskListRes, err error) {
 	res = &v1.QueryTaskListRes{}
-	userID := g.RequestFromCtx(ctx).Header.Get("X-User-ID")
+	user := access.CurrentUser(ctx)
 	if len(req.RequestIDs) > 100 {
 		return nil, gerror.New("请求ID数量超过限制：最多100个")
 	}
 
 	cols := dao.Transcription.Columns()
-	if err = dao.Transcription.Ctx(ctx).
-		Where(cols.Owner+" = ?", userID).
+	visible, args := access.VisibleCondition(ctx, user)
+	records, err := dao.Transcription.Ctx(ctx).
+		Fields(transcription.MetaFields()...).
+		Where(visible, args...).
 		WhereIn(cols.RequestId, req.RequestIDs).
-		Scan(&res.TaskMetas); err != nil {
+		All()
+	if err != nil {
 		return nil, gerror.Wrap(err, "查询数据库失败")
 	}
+	if listNotModified(ctx, records, nil) {
+		return nil, nil
+	}
+	if err = records.Structs(&res.TaskMetas); err != nil {
+		return nil, gerror.Wrap(err, "解析任务列表失败")
+	}
 	return res, nil
 }
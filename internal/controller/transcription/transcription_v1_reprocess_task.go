@@ -0,0 +1,25 @@
+package transcription
+
+import (
+	"context"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) ReprocessTask(ctx context.Context, req *v1.ReprocessTaskReq) (res *v1.ReprocessTaskRes, err error) {
+	user := access.CurrentUser(ctx)
+	if _, err = access.Require(ctx, req.RequestId, user, access.RoleEditor); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	version, err := transcription.Reprocess(ctx, record, req.Params, user.ID, req.Activate)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ReprocessTaskRes{Version: version.Version, Status: version.Status}, nil
+}
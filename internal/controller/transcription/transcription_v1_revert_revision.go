@@ -0,0 +1,25 @@
+package transcription
+
+import (
+	"context"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) RevertRevision(ctx context.Context, req *v1.RevertRevisionReq) (res *v1.RevertRevisionRes, err error) {
+	user := access.CurrentUser(ctx)
+	if _, err = access.Require(ctx, req.RequestId, user, access.RoleEditor); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := transcription.RevertRevision(ctx, record, user.ID, req.Revision)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.RevertRevisionRes{Revision: revision}, nil
+}
@@ -0,0 +1,40 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) RevokeShareLink(ctx context.Context, req *v1.RevokeShareLinkReq) (res *v1.RevokeShareLinkRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	cols := dao.TranscriptionShareLink.Columns()
+	sqlRes, err := dao.TranscriptionShareLink.Ctx(ctx).
+		Data(g.Map{
+			cols.RevokedAt: gtime.Now(),
+			cols.UpdatedAt: gtime.Now(),
+		}).
+		Where(cols.Token+" = ?", req.Token).
+		Where(cols.RequestId+" = ?", req.RequestId).
+		WhereNull(cols.RevokedAt).
+		Update()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "撤销分享链接失败")
+	}
+	if affected, err := sqlRes.RowsAffected(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "检查撤销情况失败")
+	} else if affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "找不到分享链接或链接已被撤销")
+	}
+	return &v1.RevokeShareLinkRes{Success: true}, nil
+}
@@ -0,0 +1,25 @@
+package transcription
+
+import (
+	"context"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) SaveCorrections(ctx context.Context, req *v1.SaveCorrectionsReq) (res *v1.SaveCorrectionsRes, err error) {
+	user := access.CurrentUser(ctx)
+	if _, err = access.Require(ctx, req.RequestId, user, access.RoleEditor); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := transcription.SaveCorrections(ctx, record, user.ID, req.BaseRevision, toLarkCorrections(req.Corrections), req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SaveCorrectionsRes{Revision: revision}, nil
+}
@@ -5,43 +5,104 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/errors/gerror"
-	"github.com/gogf/gf/v2/frame/g"
 
 	v1 "doubao-speech-service/api/transcription/v1"
 	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/pagination"
+	"doubao-speech-service/internal/service/transcription"
 )
 
+// searchMatchesPerTask 是搜索结果中每个任务返回的命中片段数
+const searchMatchesPerTask = 5
+
 func (c *ControllerV1) Search(ctx context.Context, req *v1.SearchReq) (res *v1.SearchRes, err error) {
-	res = &v1.SearchRes{}
-	userID := g.RequestFromCtx(ctx).Header.Get("X-User-ID")
 	keyword := strings.TrimSpace(req.Keyword)
-	if keyword == "" {
-		return nil, gerror.New("关键词不能为空")
+	// v1 只返回第一页，不统计总数
+	records, page, err := searchRecords(ctx, keyword, req.WorkspaceId, req.TaskListFilter, toPageParams(v1.PageReq{Total: pagination.TotalNone}, req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	if listNotModified(ctx, records, page) {
+		return nil, nil
+	}
+	hits, err := toSearchHits(ctx, records, keyword)
+	if err != nil {
+		return nil, err
 	}
+	list := v1.SearchRes(hits)
+	return &list, nil
+}
 
-	limit := req.Limit
-	if limit <= 0 {
-		limit = 20
+// searchRecords 按关键词查询当前用户个人空间或指定团队空间中的任务，只读取任务元数据。
+func searchRecords(ctx context.Context, keyword, workspaceId string, f v1.TaskListFilter, params pagination.Params) (gdb.Result, *pagination.Page, error) {
+	user := access.CurrentUser(ctx)
+	if keyword == "" {
+		return nil, nil, gerror.New("关键词不能为空")
 	}
-	if limit > 100 {
-		limit = 100
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
 	}
 
 	cols := dao.Transcription.Columns()
+	speakerCols := dao.TranscriptionSpeaker.Columns()
+	// 说话人映射后的名称、转写和总结的全文也可以搜索
 	condition := fmt.Sprintf(
-		"(%s ILIKE ? OR COALESCE(%s->>'filename', '') ILIKE ?)",
-		cols.RequestId, cols.FileInfo,
+		`(%[1]s ILIKE ? ESCAPE '\' OR COALESCE(%[2]s->>'filename', '') ILIKE ? ESCAPE '\' OR COALESCE(%[3]s, '') ILIKE ? ESCAPE '\' OR COALESCE(%[4]s, '') ILIKE ? ESCAPE '\' OR %[5]s::text ILIKE ? ESCAPE '\' OR %[1]s IN (SELECT %[6]s FROM %[7]s WHERE %[8]s ILIKE ? ESCAPE '\') OR %[9]s)`,
+		cols.RequestId, cols.FileInfo, cols.Title, cols.Description, cols.Tags,
+		speakerCols.RequestId, dao.TranscriptionSpeaker.Table(), speakerCols.Name,
+		transcription.TextMatchCondition(cols.RequestId),
 	)
+	like := transcription.ContainsPattern(keyword)
+	filter := toTaskFilter(f)
 
-	if err = dao.Transcription.Ctx(ctx).
-		Where(cols.Owner+" = ?", userID).
-		Where(condition, "%"+keyword+"%", "%"+keyword+"%").
-		OrderDesc(cols.CreatedAt).
-		OrderDesc(cols.Id).
-		Limit(limit).
-		Scan(res); err != nil {
-		return nil, gerror.Wrap(err, "查询数据库失败")
+	model := dao.Transcription.Ctx(ctx).Handler(filter.Apply)
+	if workspaceId != "" {
+		if _, err := access.RequireWorkspace(ctx, workspaceId, user, access.WorkspaceRoleViewer); err != nil {
+			return nil, nil, err
+		}
+		model = model.Where(cols.WorkspaceId+" = ?", workspaceId)
+	} else {
+		model = model.Where(cols.Owner+" = ?", user.ID).WhereNull(cols.WorkspaceId)
+	}
+
+	keyset := transcription.TaskSort{Desc: true}.Keyset()
+	return pagination.Query(ctx, model.Where(condition, like, like, like, like, like, like, like), keyset, cols.Id, params, transcription.MetaFields()...)
+}
+
+// toSearchHits 给搜索到的任务附上转写、总结和待办中命中的片段。
+func toSearchHits(ctx context.Context, records gdb.Result, keyword string) ([]v1.SearchHit, error) {
+	var metas []v1.TaskMeta
+	if err := records.Structs(&metas); err != nil {
+		return nil, gerror.Wrap(err, "解析搜索结果失败")
+	}
+
+	requestIds := make([]string, 0, len(metas))
+	for _, meta := range metas {
+		requestIds = append(requestIds, meta.RequestId)
+	}
+	matches, err := transcription.SearchText(ctx, requestIds, keyword, searchMatchesPerTask)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]v1.SearchHit, 0, len(metas))
+	for _, meta := range metas {
+		hit := v1.SearchHit{TaskMeta: meta, Matches: make([]v1.SearchMatch, 0, len(matches[meta.RequestId]))}
+		for _, m := range matches[meta.RequestId] {
+			hit.Matches = append(hit.Matches, v1.SearchMatch{
+				Kind:       m.Kind,
+				SentenceId: m.SentenceId,
+				Snippet:    m.Snippet,
+				StartTime:  m.StartTime,
+				EndTime:    m.EndTime,
+			})
+		}
+		hits = append(hits, hit)
 	}
-	return res, nil
+	return hits, nil
 }
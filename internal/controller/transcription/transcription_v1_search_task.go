@@ -0,0 +1,41 @@
+package transcription
+
+import (
+	"context"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) SearchTask(ctx context.Context, req *v1.SearchTaskReq) (res *v1.SearchTaskRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	_, result, err := transcription.LoadResult(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	found, total, err := transcription.Find(result, transcription.FindOptions{
+		Query:       req.Q,
+		Mode:        req.Mode,
+		Translation: req.Translation,
+		Context:     req.Context,
+		Limit:       req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.SearchTaskRes{Total: total, Matches: make([]v1.TranscriptHit, 0, len(found))}
+	for _, f := range found {
+		res.Matches = append(res.Matches, v1.TranscriptHit{
+			TranscriptSentence: toTranscriptSentence(f.Sentence),
+			Field:              f.Field,
+			Offsets:            f.Offsets,
+			Before:             toTranscriptSentences(f.Before),
+			After:              toTranscriptSentences(f.After),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,46 @@
+package transcription
+
+import (
+	"context"
+	"strings"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) SetSpeakers(ctx context.Context, req *v1.SetSpeakersReq) (res *v1.SetSpeakersRes, err error) {
+	user := access.CurrentUser(ctx)
+	if _, err = access.Require(ctx, req.RequestId, user, access.RoleEditor); err != nil {
+		return nil, err
+	}
+	record, err := transcription.GetRecord(ctx, req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]lark.SpeakerProfile, 0, len(req.Speakers))
+	for _, s := range req.Speakers {
+		p := lark.SpeakerProfile{
+			SpeakerId: strings.TrimSpace(s.Id),
+			Name:      strings.TrimSpace(s.Name),
+			Profile:   strings.TrimSpace(s.Profile),
+			MergeInto: strings.TrimSpace(s.MergeInto),
+		}
+		// 没有任何修改的条目不保存
+		if p.Name == "" && p.Profile == "" && p.MergeInto == "" {
+			continue
+		}
+		profiles = append(profiles, p)
+	}
+	if err = transcription.SetSpeakers(ctx, record, user.ID, profiles); err != nil {
+		return nil, err
+	}
+
+	infos, err := transcription.Speakers(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SetSpeakersRes{Speakers: toTaskSpeakers(infos)}, nil
+}
@@ -0,0 +1,50 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) ShareTask(ctx context.Context, req *v1.ShareTaskReq) (res *v1.ShareTaskRes, err error) {
+	user := access.CurrentUser(ctx)
+	if _, err = access.Require(ctx, req.RequestId, user, access.RoleOwner); err != nil {
+		return nil, err
+	}
+	if req.GranteeType == access.GranteeUser && req.Grantee == user.ID {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "不能共享给自己")
+	}
+
+	cols := dao.TranscriptionShare.Columns()
+	if _, err = dao.TranscriptionShare.Ctx(ctx).
+		Data(g.Map{
+			cols.RequestId:   req.RequestId,
+			cols.GranteeType: req.GranteeType,
+			cols.Grantee:     req.Grantee,
+			cols.Role:        req.Role,
+			cols.CreatedBy:   user.ID,
+			cols.UpdatedAt:   gtime.Now(),
+		}).
+		OnConflict(cols.RequestId, cols.GranteeType, cols.Grantee).
+		Save(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "保存共享记录失败")
+	}
+
+	res = &v1.ShareTaskRes{}
+	if err = dao.TranscriptionShare.Ctx(ctx).
+		Where(cols.RequestId+" = ?", req.RequestId).
+		Where(cols.GranteeType+" = ?", req.GranteeType).
+		Where(cols.Grantee+" = ?", req.Grantee).
+		Limit(1).
+		Scan(res); err != nil {
+		return nil, gerror.Wrap(err, "查询共享记录失败")
+	}
+	return res, nil
+}
@@ -0,0 +1,61 @@
+package transcription
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV1) UpdateTask(ctx context.Context, req *v1.UpdateTaskReq) (res *v1.UpdateTaskRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	cols := dao.Transcription.Columns()
+	data := g.Map{}
+	if req.Title != nil {
+		data[cols.Title] = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		data[cols.Description] = *req.Description
+	}
+	if req.Tags != nil {
+		tags, err := transcription.NormalizeTags(*req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		data[cols.Tags] = tags
+	}
+	if req.Folder != nil {
+		data[cols.Folder] = strings.Trim(strings.TrimSpace(*req.Folder), "/")
+	}
+	if len(data) == 0 {
+		return nil, gerror.NewCode(gcode.CodeMissingParameter, "没有需要修改的字段")
+	}
+	data[cols.UpdatedAt] = gtime.Now()
+
+	if _, err = dao.Transcription.Ctx(ctx).
+		Data(data).
+		Where(cols.RequestId+" = ?", req.RequestId).
+		Update(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "修改任务元数据失败")
+	}
+
+	res = &v1.UpdateTaskRes{}
+	if err = dao.Transcription.Ctx(ctx).
+		Where(cols.RequestId+" = ?", req.RequestId).
+		Limit(1).
+		Scan(res); err != nil {
+		return nil, gerror.Wrap(err, "查询任务记录失败")
+	}
+	return res, nil
+}
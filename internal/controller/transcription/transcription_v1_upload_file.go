@@ -13,7 +13,9 @@ import (
 
 	v1 "doubao-speech-service/api/transcription/v1"
 	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
 	meetingRecordSvc "doubao-speech-service/internal/service/meetingRecord"
+	"doubao-speech-service/internal/service/volcengine"
 )
 
 func (c *ControllerV1) UploadFile(ctx context.Context, req *v1.UploadFileReq) (res *v1.UploadFileRes, err error) {
@@ -23,7 +25,13 @@ func (c *ControllerV1) UploadFile(ctx context.Context, req *v1.UploadFileReq) (r
 		return nil, gerror.New("上传文件为空，请使用字段名'files'上传文件")
 	}
 
-	userID := g.RequestFromCtx(ctx).Header.Get("X-User-ID")
+	user := access.CurrentUser(ctx)
+	userID := user.ID
+	if req.WorkspaceId != "" {
+		if _, err := access.RequireWorkspace(ctx, req.WorkspaceId, user, access.WorkspaceRoleEditor); err != nil {
+			return nil, err
+		}
+	}
 	uploadDir := g.Cfg().MustGet(ctx, "meeting.record.dir", "/app/uploads").String()
 
 	// 创建存储目录
@@ -67,17 +75,38 @@ func (c *ControllerV1) UploadFile(ctx context.Context, req *v1.UploadFileReq) (r
 			continue
 		}
 
+		// 探测媒体信息，没有音频流的文件直接拒绝，不创建记录
+		mediaInfo := volcengine.ProbeMedia(ctx, localPath)
+		if err := volcengine.CheckMedia(mediaInfo); err != nil {
+			errorFiles = append(errorFiles, v1.FileError{
+				FileName: file.Filename,
+				Error:    err.Error(),
+			})
+			_ = os.Remove(localPath)
+			continue
+		}
+		var duration int64
+		if mediaInfo != nil {
+			duration = mediaInfo.Duration
+		}
+
 		// 2. 创建 pending 记录
+		var workspaceID any
+		if req.WorkspaceId != "" {
+			workspaceID = req.WorkspaceId
+		}
 		if _, err := dao.Transcription.Ctx(ctx).Data(g.Map{
-			"request_id": requestID,
-			"owner":      userID,
+			"request_id":   requestID,
+			"owner":        userID,
+			"workspace_id": workspaceID,
 			"file_info": g.Map{
 				"object_key": fmt.Sprintf("%s/%s", requestID, fileName),
 				"filename":   file.Filename,
 				"file_type":  "Pending Inspection", // 待检测
 				"file_size":  file.Size,
 			},
-			"status": "pending",
+			"duration": duration,
+			"status":   "pending",
 		}).Insert(); err != nil {
 			errorFiles = append(errorFiles, v1.FileError{
 				FileName: file.Filename,
@@ -99,14 +128,17 @@ func (c *ControllerV1) UploadFile(ctx context.Context, req *v1.UploadFileReq) (r
 			Size:      file.Size,
 			StartedAt: time.Now(),
 			EndedAt:   time.Now(),
+			MediaInfo: mediaInfo,
 		})
 
 		// 4. 立即返回 TaskMeta（不等待上传完成）
 		successTaskMetas = append(successTaskMetas, v1.TaskMeta{
-			RequestId: requestID,
-			Owner:     userID,
-			Status:    "pending",
-			CreatedAt: nil,
+			RequestId:   requestID,
+			Owner:       userID,
+			WorkspaceId: req.WorkspaceId,
+			Duration:    duration,
+			Status:      "pending",
+			CreatedAt:   nil,
 		})
 	}
 
@@ -0,0 +1,50 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v2 "doubao-speech-service/api/transcription/v2"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/transcription"
+)
+
+func (c *ControllerV2) GetTask(ctx context.Context, req *v2.GetTaskReq) (res *v2.GetTaskRes, err error) {
+	if _, err = access.Require(ctx, req.RequestId, access.CurrentUser(ctx), access.RoleViewer); err != nil {
+		return nil, err
+	}
+	if hit, err := notModified(ctx, req.RequestId); err != nil || hit {
+		return nil, err
+	}
+
+	row, err := dao.Transcription.Ctx(ctx).
+		Where(dao.Transcription.Columns().RequestId+" = ?", req.RequestId).
+		One()
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取任务记录失败")
+	}
+	if row.IsEmpty() {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "任务不存在")
+	}
+
+	var record *entity.Transcription
+	res = &v2.GetTaskRes{}
+	if err = row.Struct(&record); err != nil {
+		return nil, gerror.Wrap(err, "解析任务记录失败")
+	}
+	if err = row.Struct(&res.TaskMeta); err != nil {
+		return nil, gerror.Wrap(err, "解析任务记录失败")
+	}
+	revision := transcription.LatestRevision
+	if req.Revision != nil {
+		revision = *req.Revision
+	}
+	if res.Result, res.Revision, err = transcription.ResultAt(ctx, record, revision); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
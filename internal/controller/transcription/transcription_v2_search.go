@@ -0,0 +1,24 @@
+package transcription
+
+import (
+	"context"
+	"strings"
+
+	v2 "doubao-speech-service/api/transcription/v2"
+)
+
+func (c *ControllerV2) Search(ctx context.Context, req *v2.SearchReq) (res *v2.SearchRes, err error) {
+	keyword := strings.TrimSpace(req.Keyword)
+	records, page, err := searchRecords(ctx, keyword, req.WorkspaceId, req.TaskListFilter, toPageParams(req.PageReq, req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	if listNotModified(ctx, records, page) {
+		return nil, nil
+	}
+	res = &v2.SearchRes{PageInfo: page.Info()}
+	if res.TaskMetas, err = toSearchHits(ctx, records, keyword); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,50 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+// getWorkspace 查询团队空间，并附上当前用户的角色。
+func getWorkspace(ctx context.Context, workspaceId string, role access.WorkspaceRole) (*v1.Workspace, error) {
+	var workspace *v1.Workspace
+	cols := dao.Workspace.Columns()
+	if err := dao.Workspace.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", workspaceId).
+		Limit(1).
+		Scan(&workspace); err != nil {
+		return nil, gerror.Wrap(err, "查询团队空间失败")
+	}
+	if workspace == nil {
+		return nil, gerror.Newf("团队空间不存在：%s", workspaceId)
+	}
+	workspace.Role = role.String()
+	return workspace, nil
+}
+
+// ensureAnotherOwner 确保移除或降级 member 之后，空间内仍然至少有一个 owner。
+func ensureAnotherOwner(ctx context.Context, workspaceId, member string) error {
+	cols := dao.WorkspaceMember.Columns()
+	count, err := dao.WorkspaceMember.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", workspaceId).
+		Where(cols.Role+" = ?", access.WorkspaceRoleOwner.String()).
+		WhereNot(cols.Member, member).
+		Count()
+	if err != nil {
+		return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询团队空间 owner 失败")
+	}
+	if count == 0 {
+		return gerror.NewCode(gcode.CodeInvalidOperation, "团队空间至少需要保留一个 owner")
+	}
+	return nil
+}
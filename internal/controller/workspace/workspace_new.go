@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package workspace
+
+import (
+	"doubao-speech-service/api/workspace"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() workspace.IWorkspaceV1 {
+	return &ControllerV1{}
+}
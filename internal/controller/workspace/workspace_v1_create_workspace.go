@@ -0,0 +1,53 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/guid"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) CreateWorkspace(ctx context.Context, req *v1.CreateWorkspaceReq) (res *v1.CreateWorkspaceRes, err error) {
+	user := access.CurrentUser(ctx)
+	if user.ID == "" {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "userID is required")
+	}
+
+	workspaceId := guid.S()
+	err = dao.Workspace.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		wsCols := dao.Workspace.Columns()
+		if _, err := dao.Workspace.Ctx(ctx).Data(g.Map{
+			wsCols.WorkspaceId: workspaceId,
+			wsCols.Name:        req.Name,
+			wsCols.Description: req.Description,
+			wsCols.CreatedBy:   user.ID,
+		}).Insert(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "创建团队空间失败")
+		}
+		memberCols := dao.WorkspaceMember.Columns()
+		if _, err := dao.WorkspaceMember.Ctx(ctx).Data(g.Map{
+			memberCols.WorkspaceId: workspaceId,
+			memberCols.Member:      user.ID,
+			memberCols.Role:        access.WorkspaceRoleOwner.String(),
+		}).Insert(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "添加团队空间 owner 失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := getWorkspace(ctx, workspaceId, access.WorkspaceRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	return (*v1.CreateWorkspaceRes)(workspace), nil
+}
@@ -0,0 +1,31 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) DeleteMinutesTemplate(ctx context.Context, req *v1.DeleteMinutesTemplateReq) (res *v1.DeleteMinutesTemplateRes, err error) {
+	if _, err = access.RequireWorkspace(ctx, req.WorkspaceId, access.CurrentUser(ctx), access.WorkspaceRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	cols := dao.MinutesTemplate.Columns()
+	result, err := dao.MinutesTemplate.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", req.WorkspaceId).
+		Where(cols.Name+" = ?", req.Name).
+		Delete()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "删除纪要模板失败")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, "纪要模板不存在：%s", req.Name)
+	}
+	return &v1.DeleteMinutesTemplateRes{Success: true}, nil
+}
@@ -0,0 +1,51 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) DeleteWorkspace(ctx context.Context, req *v1.DeleteWorkspaceReq) (res *v1.DeleteWorkspaceRes, err error) {
+	if _, err = access.RequireWorkspace(ctx, req.WorkspaceId, access.CurrentUser(ctx), access.WorkspaceRoleOwner); err != nil {
+		return nil, err
+	}
+
+	err = dao.Workspace.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		// 空间内的任务移回各自拥有者的个人空间
+		transCols := dao.Transcription.Columns()
+		if _, err := dao.Transcription.Ctx(ctx).
+			Data(g.Map{transCols.WorkspaceId: nil}).
+			Where(transCols.WorkspaceId+" = ?", req.WorkspaceId).
+			Update(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "移出空间内的任务失败")
+		}
+		if _, err := dao.WorkspaceMember.Ctx(ctx).
+			Where(dao.WorkspaceMember.Columns().WorkspaceId+" = ?", req.WorkspaceId).
+			Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除团队空间成员失败")
+		}
+		if _, err := dao.MinutesTemplate.Ctx(ctx).
+			Where(dao.MinutesTemplate.Columns().WorkspaceId+" = ?", req.WorkspaceId).
+			Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除团队空间纪要模板失败")
+		}
+		if _, err := dao.Workspace.Ctx(ctx).
+			Where(dao.Workspace.Columns().WorkspaceId+" = ?", req.WorkspaceId).
+			Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "删除团队空间失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1.DeleteWorkspaceRes{Success: true}, nil
+}
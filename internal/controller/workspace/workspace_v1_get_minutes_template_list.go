@@ -0,0 +1,29 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/minutes"
+)
+
+func (c *ControllerV1) GetMinutesTemplateList(ctx context.Context, req *v1.GetMinutesTemplateListReq) (res *v1.GetMinutesTemplateListRes, err error) {
+	if _, err = access.RequireWorkspace(ctx, req.WorkspaceId, access.CurrentUser(ctx), access.WorkspaceRoleViewer); err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetMinutesTemplateListRes{Default: minutes.DefaultTemplate}
+	cols := dao.MinutesTemplate.Columns()
+	if err = dao.MinutesTemplate.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", req.WorkspaceId).
+		OrderAsc(cols.Name).
+		Scan(&res.Templates); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询纪要模板失败")
+	}
+	return res, nil
+}
@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) GetWorkspace(ctx context.Context, req *v1.GetWorkspaceReq) (res *v1.GetWorkspaceRes, err error) {
+	role, err := access.RequireWorkspace(ctx, req.WorkspaceId, access.CurrentUser(ctx), access.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := getWorkspace(ctx, req.WorkspaceId, role)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetWorkspaceRes{
+		Workspace: *workspace,
+		Members:   []v1.Member{},
+	}
+	cols := dao.WorkspaceMember.Columns()
+	if err = dao.WorkspaceMember.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", req.WorkspaceId).
+		OrderAsc(cols.Id).
+		Scan(&res.Members); err != nil {
+		return nil, gerror.Wrap(err, "查询团队空间成员失败")
+	}
+	return res, nil
+}
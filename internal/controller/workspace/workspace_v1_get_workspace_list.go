@@ -0,0 +1,50 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/pagination"
+)
+
+func (c *ControllerV1) GetWorkspaceList(ctx context.Context, req *v1.GetWorkspaceListReq) (res *v1.GetWorkspaceListRes, err error) {
+	res = &v1.GetWorkspaceListRes{Workspaces: []v1.Workspace{}}
+	user := access.CurrentUser(ctx)
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	wsCols := dao.Workspace.Columns()
+	memberCols := dao.WorkspaceMember.Columns()
+	model := dao.Workspace.Ctx(ctx).As("w").
+		InnerJoin(dao.WorkspaceMember.Table()+" m", fmt.Sprintf("m.%s = w.%s", memberCols.WorkspaceId, wsCols.WorkspaceId)).
+		Where("m."+memberCols.Member+" = ?", user.ID)
+	keyset := pagination.Keyset{
+		Expr: "w." + wsCols.CreatedAt,
+		Id:   "w." + wsCols.Id,
+		Desc: true,
+	}
+	records, page, err := pagination.Query(ctx, model, keyset, wsCols.Id, pagination.Params{
+		Cursor: req.Cursor,
+		Limit:  limit,
+		Total:  req.Total,
+	}, "w.*", "m."+memberCols.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err = records.Structs(&res.Workspaces); err != nil {
+		return nil, gerror.Wrap(err, "查询团队空间失败")
+	}
+	res.PageInfo = page.Info()
+	return res, nil
+}
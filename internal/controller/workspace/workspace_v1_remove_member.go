@@ -0,0 +1,49 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) RemoveMember(ctx context.Context, req *v1.RemoveMemberReq) (res *v1.RemoveMemberRes, err error) {
+	user := access.CurrentUser(ctx)
+	need := access.WorkspaceRoleAdmin
+	if req.Member == user.ID {
+		// 成员可以自行退出空间
+		need = access.WorkspaceRoleViewer
+	}
+	role, err := access.RequireWorkspace(ctx, req.WorkspaceId, user, need)
+	if err != nil {
+		return nil, err
+	}
+	target, err := access.GetWorkspaceRole(ctx, req.WorkspaceId, access.User{ID: req.Member})
+	if err != nil {
+		return nil, err
+	}
+	if target == access.WorkspaceRoleNone {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "该用户不是团队空间成员")
+	}
+	if req.Member != user.ID && role < access.WorkspaceRoleOwner && target >= role {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "只有 owner 可以移除 admin 及以上角色的成员")
+	}
+	if target == access.WorkspaceRoleOwner {
+		if err = ensureAnotherOwner(ctx, req.WorkspaceId, req.Member); err != nil {
+			return nil, err
+		}
+	}
+
+	cols := dao.WorkspaceMember.Columns()
+	if _, err = dao.WorkspaceMember.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", req.WorkspaceId).
+		Where(cols.Member+" = ?", req.Member).
+		Delete(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "移除团队空间成员失败")
+	}
+	return &v1.RemoveMemberRes{Success: true}, nil
+}
@@ -0,0 +1,59 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) SetMember(ctx context.Context, req *v1.SetMemberReq) (res *v1.SetMemberRes, err error) {
+	user := access.CurrentUser(ctx)
+	role, err := access.RequireWorkspace(ctx, req.WorkspaceId, user, access.WorkspaceRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	// admin 只能管理比自己角色低的成员，owner 不受限制
+	target, err := access.GetWorkspaceRole(ctx, req.WorkspaceId, access.User{ID: req.Member})
+	if err != nil {
+		return nil, err
+	}
+	newRole := access.ParseWorkspaceRole(req.Role)
+	if role < access.WorkspaceRoleOwner && (newRole >= role || target >= role) {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "只有 owner 可以授予或修改 admin 及以上角色")
+	}
+	if target == access.WorkspaceRoleOwner && newRole < access.WorkspaceRoleOwner {
+		if err = ensureAnotherOwner(ctx, req.WorkspaceId, req.Member); err != nil {
+			return nil, err
+		}
+	}
+
+	cols := dao.WorkspaceMember.Columns()
+	if _, err = dao.WorkspaceMember.Ctx(ctx).
+		Data(g.Map{
+			cols.WorkspaceId: req.WorkspaceId,
+			cols.Member:      req.Member,
+			cols.Role:        newRole.String(),
+			cols.UpdatedAt:   gtime.Now(),
+		}).
+		OnConflict(cols.WorkspaceId, cols.Member).
+		Save(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "保存团队空间成员失败")
+	}
+
+	res = &v1.SetMemberRes{}
+	if err = dao.WorkspaceMember.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", req.WorkspaceId).
+		Where(cols.Member+" = ?", req.Member).
+		Limit(1).
+		Scan(res); err != nil {
+		return nil, gerror.Wrap(err, "查询团队空间成员失败")
+	}
+	return res, nil
+}
@@ -0,0 +1,55 @@
+package workspace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/minutes"
+)
+
+func (c *ControllerV1) SetMinutesTemplate(ctx context.Context, req *v1.SetMinutesTemplateReq) (res *v1.SetMinutesTemplateRes, err error) {
+	user := access.CurrentUser(ctx)
+	if _, err = access.RequireWorkspace(ctx, req.WorkspaceId, user, access.WorkspaceRoleAdmin); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "模板名称不能为空")
+	}
+	if err = minutes.Validate(req.Content); err != nil {
+		return nil, err
+	}
+
+	cols := dao.MinutesTemplate.Columns()
+	if _, err = dao.MinutesTemplate.Ctx(ctx).
+		Data(g.Map{
+			cols.WorkspaceId: req.WorkspaceId,
+			cols.Name:        name,
+			cols.Content:     req.Content,
+			cols.CreatedBy:   user.ID,
+			cols.UpdatedAt:   gtime.Now(),
+		}).
+		OnConflict(cols.WorkspaceId, cols.Name).
+		OnDuplicate(cols.Content, cols.UpdatedAt).
+		Save(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "保存纪要模板失败")
+	}
+
+	res = &v1.SetMinutesTemplateRes{}
+	if err = dao.MinutesTemplate.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", req.WorkspaceId).
+		Where(cols.Name+" = ?", name).
+		Limit(1).
+		Scan(res); err != nil {
+		return nil, gerror.Wrap(err, "查询纪要模板失败")
+	}
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	v1 "doubao-speech-service/api/workspace/v1"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+)
+
+func (c *ControllerV1) UpdateWorkspace(ctx context.Context, req *v1.UpdateWorkspaceReq) (res *v1.UpdateWorkspaceRes, err error) {
+	role, err := access.RequireWorkspace(ctx, req.WorkspaceId, access.CurrentUser(ctx), access.WorkspaceRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := dao.Workspace.Columns()
+	if _, err = dao.Workspace.Ctx(ctx).
+		Data(g.Map{
+			cols.Name:        req.Name,
+			cols.Description: req.Description,
+			cols.UpdatedAt:   gtime.Now(),
+		}).
+		Where(cols.WorkspaceId+" = ?", req.WorkspaceId).
+		Update(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "修改团队空间失败")
+	}
+
+	workspace, err := getWorkspace(ctx, req.WorkspaceId, role)
+	if err != nil {
+		return nil, err
+	}
+	return (*v1.UpdateWorkspaceRes)(workspace), nil
+}
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// minutesTemplateDao is the data access object for the table minutes_template.
+// You can define custom methods on it to extend its functionality as needed.
+type minutesTemplateDao struct {
+	*internal.MinutesTemplateDao
+}
+
+var (
+	// MinutesTemplate is a globally accessible object for table minutes_template operations.
+	MinutesTemplate = minutesTemplateDao{internal.NewMinutesTemplateDao()}
+)
+
+// Add your custom methods and functionality below.
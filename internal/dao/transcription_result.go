@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// transcriptionResultDao is the data access object for the table transcription_result.
+// You can define custom methods on it to extend its functionality as needed.
+type transcriptionResultDao struct {
+	*internal.TranscriptionResultDao
+}
+
+var (
+	// TranscriptionResult is a globally accessible object for table transcription_result operations.
+	TranscriptionResult = transcriptionResultDao{internal.NewTranscriptionResultDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// transcriptionRevisionDao is the data access object for the table transcription_revision.
+// You can define custom methods on it to extend its functionality as needed.
+type transcriptionRevisionDao struct {
+	*internal.TranscriptionRevisionDao
+}
+
+var (
+	// TranscriptionRevision is a globally accessible object for table transcription_revision operations.
+	TranscriptionRevision = transcriptionRevisionDao{internal.NewTranscriptionRevisionDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// transcriptionShareDao is the data access object for the table transcription_share.
+// You can define custom methods on it to extend its functionality as needed.
+type transcriptionShareDao struct {
+	*internal.TranscriptionShareDao
+}
+
+var (
+	// TranscriptionShare is a globally accessible object for table transcription_share operations.
+	TranscriptionShare = transcriptionShareDao{internal.NewTranscriptionShareDao()}
+)
+
+// Add your custom methods and functionality below.
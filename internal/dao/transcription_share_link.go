@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// transcriptionShareLinkDao is the data access object for the table transcription_share_link.
+// You can define custom methods on it to extend its functionality as needed.
+type transcriptionShareLinkDao struct {
+	*internal.TranscriptionShareLinkDao
+}
+
+var (
+	// TranscriptionShareLink is a globally accessible object for table transcription_share_link operations.
+	TranscriptionShareLink = transcriptionShareLinkDao{internal.NewTranscriptionShareLinkDao()}
+)
+
+// Add your custom methods and functionality below.
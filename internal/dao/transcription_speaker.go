@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// transcriptionSpeakerDao is the data access object for the table transcription_speaker.
+// You can define custom methods on it to extend its functionality as needed.
+type transcriptionSpeakerDao struct {
+	*internal.TranscriptionSpeakerDao
+}
+
+var (
+	// TranscriptionSpeaker is a globally accessible object for table transcription_speaker operations.
+	TranscriptionSpeaker = transcriptionSpeakerDao{internal.NewTranscriptionSpeakerDao()}
+)
+
+// Add your custom methods and functionality below.
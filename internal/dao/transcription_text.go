@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// transcriptionTextDao is the data access object for the table transcription_text.
+// You can define custom methods on it to extend its functionality as needed.
+type transcriptionTextDao struct {
+	*internal.TranscriptionTextDao
+}
+
+var (
+	// TranscriptionText is a globally accessible object for table transcription_text operations.
+	TranscriptionText = transcriptionTextDao{internal.NewTranscriptionTextDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// transcriptionVersionDao is the data access object for the table transcription_version.
+// You can define custom methods on it to extend its functionality as needed.
+type transcriptionVersionDao struct {
+	*internal.TranscriptionVersionDao
+}
+
+var (
+	// TranscriptionVersion is a globally accessible object for table transcription_version operations.
+	TranscriptionVersion = transcriptionVersionDao{internal.NewTranscriptionVersionDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// workspaceDao is the data access object for the table workspace.
+// You can define custom methods on it to extend its functionality as needed.
+type workspaceDao struct {
+	*internal.WorkspaceDao
+}
+
+var (
+	// Workspace is a globally accessible object for table workspace operations.
+	Workspace = workspaceDao{internal.NewWorkspaceDao()}
+)
+
+// Add your custom methods and functionality below.
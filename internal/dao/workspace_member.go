@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"doubao-speech-service/internal/dao/internal"
+)
+
+// workspaceMemberDao is the data access object for the table workspace_member.
+// You can define custom methods on it to extend its functionality as needed.
+type workspaceMemberDao struct {
+	*internal.WorkspaceMemberDao
+}
+
+var (
+	// WorkspaceMember is a globally accessible object for table workspace_member operations.
+	WorkspaceMember = workspaceMemberDao{internal.NewWorkspaceMemberDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,418 @@
+package middlewares
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/klauspost/compress/zstd"
+
+	"doubao-speech-service/internal/service/conditional"
+)
+
+// 支持的压缩编码
+const (
+	encodingBrotli = "br"
+	encodingZstd   = "zstd"
+	encodingGzip   = "gzip"
+)
+
+// defaultLevels 是各编码的默认压缩级别。Brotli 的最高级别 11 压缩几 MB 的转写 JSON 要上百毫秒，默认使用 5。
+var defaultLevels = map[string]int{
+	encodingBrotli: 5,
+	encodingZstd:   3,
+	encodingGzip:   6,
+}
+
+// compressConfig 是响应压缩的配置，对应配置项 server.compression。
+type compressConfig struct {
+	Enabled   bool
+	Encodings []string                  // 支持的编码，按服务端偏好排序；客户端 q 值相同时取靠前的编码
+	MinSize   int                       // 小于该字节数的响应不压缩
+	Levels    map[string]map[string]int // Content-Type（如 application/json、text/*、*）→ 编码 → 压缩级别
+	SkipTypes []string                  // 不压缩的 Content-Type，以 / 结尾时按前缀匹配
+}
+
+var (
+	compressOnce sync.Once
+	compressCfg  *compressConfig
+)
+
+func loadCompressConfig(ctx context.Context) *compressConfig {
+	compressOnce.Do(func() {
+		cfg := &compressConfig{
+			Enabled: g.Cfg().MustGet(ctx, "server.compression.enabled", true).Bool(),
+			MinSize: g.Cfg().MustGet(ctx, "server.compression.minSize", 1024).Int(),
+			SkipTypes: g.Cfg().MustGet(ctx, "server.compression.skipTypes", []string{
+				"image/", "audio/", "video/", "font/woff", "font/woff2",
+				"application/zip", "application/gzip", "application/x-gzip", "application/zstd",
+				"application/octet-stream", "application/pdf", "text/event-stream",
+			}).Strings(),
+			Levels: map[string]map[string]int{"*": defaultLevels},
+		}
+		for _, encoding := range g.Cfg().MustGet(ctx, "server.compression.encodings", []string{encodingBrotli, encodingZstd, encodingGzip}).Strings() {
+			if _, ok := defaultLevels[encoding]; !ok {
+				g.Log().Warningf(ctx, "不支持的压缩编码：%s，已忽略", encoding)
+				continue
+			}
+			cfg.Encodings = append(cfg.Encodings, encoding)
+		}
+		for contentType, levels := range g.Cfg().MustGet(ctx, "server.compression.levels").Map() {
+			merged := make(map[string]int, len(defaultLevels))
+			for encoding, level := range defaultLevels {
+				merged[encoding] = level
+			}
+			for encoding, level := range gconv.Map(levels) {
+				merged[encoding] = gconv.Int(level)
+			}
+			cfg.Levels[strings.ToLower(contentType)] = merged
+		}
+		compressCfg = cfg
+	})
+	return compressCfg
+}
+
+// level 返回某个 Content-Type 使用的压缩级别，依次匹配完整类型、text/* 形式的大类和 *。
+func (c *compressConfig) level(contentType, encoding string) int {
+	mediaType := strings.ToLower(contentType)
+	if t, _, err := mime.ParseMediaType(contentType); err == nil {
+		mediaType = t
+	}
+	major, _, _ := strings.Cut(mediaType, "/")
+	for _, key := range []string{mediaType, major + "/*", "*"} {
+		if levels, ok := c.Levels[key]; ok {
+			if level, ok := levels[encoding]; ok {
+				return level
+			}
+		}
+	}
+	return defaultLevels[encoding]
+}
+
+func (c *compressConfig) skipType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, t := range c.SkipTypes {
+		if strings.HasSuffix(t, "/") && strings.HasPrefix(contentType, t) {
+			return true
+		}
+		if contentType == t || strings.HasPrefix(contentType, t+";") {
+			return true
+		}
+	}
+	return false
+}
+
+// CompressMiddleware 按请求的 Accept-Encoding 协商压缩编码（br、zstd、gzip，支持 q 值），边写边压缩响应。
+// 小于 server.compression.minSize 的响应、已经压缩过的内容（图片、音视频、压缩包等）、
+// 事件流、附件下载和部分内容（Range）响应不压缩。
+func CompressMiddleware(r *ghttp.Request) {
+	cfg := loadCompressConfig(r.Context())
+	if !cfg.Enabled || r.Header.Get("Upgrade") != "" {
+		r.Middleware.Next()
+		return
+	}
+	// Vary 头告诉代理服务器，响应内容根据 Accept-Encoding 的不同而不同。
+	// 不压缩的响应也要设置，否则缓存可能把未压缩的版本返回给支持压缩的客户端
+	r.Response.Header().Add("Vary", "Accept-Encoding")
+	encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), cfg.Encodings)
+	if encoding == "" {
+		r.Middleware.Next()
+		return
+	}
+
+	// 替换底层的 ResponseWriter：处理函数直接写出（流式输出、ServeFile）和最终输出缓冲区时都经过压缩
+	w := &compressWriter{
+		ResponseWriter: r.Response.Writer.ResponseWriter,
+		ctx:            r.Context(),
+		cfg:            cfg,
+		encoding:       encoding,
+		head:           r.Method == http.MethodHead,
+	}
+	r.Response.Writer.ResponseWriter = w
+	r.Middleware.Next()
+
+	// 缓冲区中的内容通常要等所有中间件结束后才输出，这里提前输出，以便结束压缩流。
+	// 通过 r.Response.Flush() 输出，由框架写入 Trace-ID、Server 等响应头；
+	// 它在输出后会调用 Flush，此时忽略，不足 minSize 的响应由 Close 原样输出
+	if r.Response.BufferLength() > 0 && !r.Response.IsHijacked() {
+		if r.Response.Status == 0 {
+			r.Response.WriteHeader(http.StatusOK)
+		}
+		w.final = true
+		r.Response.Flush()
+	}
+	if err := w.Close(); err != nil {
+		g.Log().Errorf(r.Context(), "结束 %s 压缩失败：%v", encoding, err)
+	}
+}
+
+// negotiateEncoding 从 Accept-Encoding 中选出 q 值最高的受支持编码，q 值相同时按 supported 的顺序。
+// 没有可接受的编码时返回空字符串。
+func negotiateEncoding(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+	accepted := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "q") {
+				if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = v
+				}
+			}
+		}
+		accepted[name] = q
+	}
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, encoding := range supported {
+		q, ok := accepted[encoding]
+		if !ok {
+			q = accepted["*"]
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// compressWriter 在响应头写出前决定是否压缩。内容不足 minSize 时先缓存，超过后开始压缩；
+// 结束时仍不足 minSize 则原样输出。
+type compressWriter struct {
+	http.ResponseWriter
+	ctx      context.Context
+	cfg      *compressConfig
+	encoding string
+	head     bool
+
+	status   int
+	buf      []byte
+	enc      encoder // 正在压缩时不为 nil
+	level    int
+	passed   bool // 已决定不压缩，直接写到底层
+	final    bool // 正在输出缓冲区中的最终内容，忽略之后的 Flush
+	finished bool
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.status != 0 || w.enc != nil || w.passed {
+		return
+	}
+	w.status = status
+	if status == http.StatusNotModified {
+		// 304 应带上与完整响应相同的 ETag
+		if etag := w.Header().Get("ETag"); etag != "" {
+			w.Header().Set("ETag", conditional.Encoded(etag, w.encoding))
+		}
+	}
+	if !w.compressible() {
+		w.passThrough()
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.enc == nil && !w.passed {
+		if w.status == 0 {
+			w.WriteHeader(http.StatusOK)
+		}
+		if w.finished && !w.passed {
+			w.passThrough()
+		}
+	}
+	switch {
+	case w.enc != nil:
+		return w.enc.Write(p)
+	case w.passed:
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.cfg.MinSize {
+		if err := w.start(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush 表示处理函数需要立即输出（流式响应），此时不再等待内容达到 minSize。
+func (w *compressWriter) Flush() {
+	if w.final {
+		return
+	}
+	if w.enc == nil && !w.passed {
+		if w.status == 0 {
+			w.WriteHeader(http.StatusOK)
+		}
+		if !w.passed {
+			if err := w.start(); err != nil {
+				return
+			}
+		}
+	}
+	if w.enc != nil {
+		if err := w.enc.Flush(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close 结束压缩流。之后的写入不再压缩。
+func (w *compressWriter) Close() error {
+	w.finished = true
+	if w.enc != nil {
+		enc := w.enc
+		w.enc = nil
+		w.passed = true
+		err := enc.Close()
+		putEncoder(w.encoding, w.level, enc)
+		return err
+	}
+	if w.status != 0 && !w.passed {
+		w.passThrough()
+	}
+	return nil
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("ResponseWriter 不支持 Hijack")
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap 供 http.ResponseController 访问底层的 ResponseWriter。
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// compressible 根据状态码和已设置的响应头判断是否可以压缩。
+func (w *compressWriter) compressible() bool {
+	if w.head || w.status < 200 || w.status >= 300 || w.status == http.StatusNoContent || w.status == http.StatusPartialContent {
+		return false
+	}
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+	if strings.HasPrefix(strings.ToLower(header.Get("Content-Disposition")), "attachment") {
+		return false
+	}
+	if contentType := header.Get("Content-Type"); contentType != "" && w.cfg.skipType(contentType) {
+		return false
+	}
+	if length := header.Get("Content-Length"); length != "" {
+		if n, err := strconv.Atoi(length); err == nil && n < w.cfg.MinSize {
+			return false
+		}
+	}
+	return true
+}
+
+// start 开始压缩：写出响应头和已缓存的内容。
+func (w *compressWriter) start() error {
+	header := w.Header()
+	if header.Get("Content-Type") == "" {
+		// 压缩后 net/http 无法再根据内容判断类型
+		header.Set("Content-Type", http.DetectContentType(w.buf))
+		if w.cfg.skipType(header.Get("Content-Type")) {
+			w.passThrough()
+			return nil
+		}
+	}
+	w.level = w.cfg.level(header.Get("Content-Type"), w.encoding)
+	enc, err := getEncoder(w.encoding, w.level, w.ResponseWriter)
+	if err != nil {
+		g.Log().Errorf(w.ctx, "创建 %s 压缩器失败：%v", w.encoding, err)
+		w.passThrough()
+		return nil
+	}
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	// 压缩后的内容与原始内容不同，强 ETag 需要区分编码
+	if etag := header.Get("ETag"); etag != "" {
+		header.Set("ETag", conditional.Encoded(etag, w.encoding))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.enc = enc
+	if len(w.buf) > 0 {
+		buf := w.buf
+		w.buf = nil
+		if _, err = enc.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// passThrough 放弃压缩：写出响应头和已缓存的内容，之后的写入直接写到底层。
+func (w *compressWriter) passThrough() {
+	w.passed = true
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if len(w.buf) > 0 {
+		buf := w.buf
+		w.buf = nil
+		_, _ = w.ResponseWriter.Write(buf)
+	}
+}
+
+// encoder 是三种压缩器的公共接口。
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// encoderPools 按“编码:级别”缓存压缩器，避免每个请求重新分配压缩窗口
+var encoderPools sync.Map
+
+func getEncoder(encoding string, level int, w io.Writer) (encoder, error) {
+	pool, _ := encoderPools.LoadOrStore(fmt.Sprintf("%s:%d", encoding, level), &sync.Pool{})
+	if enc, ok := pool.(*sync.Pool).Get().(encoder); ok {
+		enc.Reset(w)
+		return enc, nil
+	}
+	switch encoding {
+	case encodingBrotli:
+		return brotli.NewWriterLevel(w, level), nil
+	case encodingZstd:
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)), zstd.WithEncoderConcurrency(1))
+	case encodingGzip:
+		return gzip.NewWriterLevel(w, level)
+	}
+	return nil, fmt.Errorf("不支持的压缩编码：%s", encoding)
+}
+
+func putEncoder(encoding string, level int, enc encoder) {
+	enc.Reset(nil)
+	if pool, ok := encoderPools.Load(fmt.Sprintf("%s:%d", encoding, level)); ok {
+		pool.(*sync.Pool).Put(enc)
+	}
+}
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+
+	"doubao-speech-service/internal/service/conditional"
+)
+
+// ConditionalMiddleware 处理条件 GET：处理函数设置了 ETag 且与请求的 If-None-Match 匹配时，丢弃响应体并返回 304。
+// 需要放在 MiddlewareHandlerResponse 之前（外层），才能丢弃它输出的 JSON。
+func ConditionalMiddleware(r *ghttp.Request) {
+	r.Middleware.Next()
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return
+	}
+	if r.GetError() != nil || (r.Response.Status != 0 && r.Response.Status != http.StatusOK) {
+		return
+	}
+	tag := r.Response.Header().Get("ETag")
+	if !conditional.Match(r.Header.Get("If-None-Match"), tag) {
+		return
+	}
+	r.Response.ClearBuffer()
+	r.Response.Header().Del("Content-Type")
+	r.Response.Header().Del("Content-Length")
+	r.Response.Header().Del("Content-Disposition")
+	r.Response.WriteHeader(http.StatusNotModified)
+}
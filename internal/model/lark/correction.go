@@ -0,0 +1,131 @@
+package lark
+
+import (
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Correction 是对一句转写的修正，按 SentenceId 对应。字段为 nil 表示保留上游结果，SpeakerId 为空字符串同样表示不修改说话人。
+type Correction struct {
+	SentenceId string  `json:"sentenceId" dc:"句子ID"`
+	Content    *string `json:"content,omitempty" dc:"修正后的文本"`
+	SpeakerId  *string `json:"speakerId,omitempty" dc:"修正后的说话人ID（上游ID），为空表示不修改"`
+	StartTime  *int64  `json:"startTime,omitempty" dc:"修正后的开始时间（毫秒）"`
+	EndTime    *int64  `json:"endTime,omitempty" dc:"修正后的结束时间（毫秒）"`
+}
+
+// IsEmpty 判断修正是否没有修改任何字段。
+func (c Correction) IsEmpty() bool {
+	return c.Content == nil && !c.ChangesSpeaker() && c.StartTime == nil && c.EndTime == nil
+}
+
+// ChangesSpeaker 判断修正是否修改了说话人。
+func (c Correction) ChangesSpeaker() bool {
+	return c.SpeakerId != nil && *c.SpeakerId != ""
+}
+
+// Merge 把 patch 中非 nil 的字段覆盖到 c 上。
+func (c Correction) Merge(patch Correction) Correction {
+	if patch.Content != nil {
+		c.Content = patch.Content
+	}
+	if patch.ChangesSpeaker() {
+		c.SpeakerId = patch.SpeakerId
+	}
+	if patch.StartTime != nil {
+		c.StartTime = patch.StartTime
+	}
+	if patch.EndTime != nil {
+		c.EndTime = patch.EndTime
+	}
+	return c
+}
+
+// WithCorrections 返回应用了修正的结果副本，原结果不变。
+// 修改了文本的句子会丢弃单词时间序列，只修改了时间的句子按新的时间区间等比例调整单词时间；修改了时间的句子会重新按开始时间排序。
+func (r *Result) WithCorrections(corrections []Correction) *Result {
+	if r == nil || len(corrections) == 0 {
+		return r
+	}
+	bySentence := make(map[string]Correction, len(corrections))
+	for _, c := range corrections {
+		bySentence[c.SentenceId] = c
+	}
+
+	out := *r
+	out.Utterances = make([]Utterance, len(r.Utterances))
+	for i, u := range r.Utterances {
+		if c, ok := bySentence[u.SentenceId]; ok && u.SentenceId != "" {
+			start, end := u.StartTime, u.EndTime
+			if c.Content != nil && *c.Content != u.Content {
+				u.Content = *c.Content
+				u.Words = nil
+			}
+			if c.ChangesSpeaker() {
+				u.SpeakerId = *c.SpeakerId
+			}
+			if c.StartTime != nil {
+				u.StartTime = *c.StartTime
+			}
+			if c.EndTime != nil {
+				u.EndTime = *c.EndTime
+			}
+			if len(u.Words) > 0 && (u.StartTime != start || u.EndTime != end) {
+				words := make([]Word, 0, len(u.Words))
+				for _, w := range u.Words {
+					w.StartTime = RescaleTime(w.StartTime, start, end, u.StartTime, u.EndTime)
+					w.EndTime = RescaleTime(w.EndTime, start, end, u.StartTime, u.EndTime)
+					words = append(words, w)
+				}
+				u.Words = words
+			}
+		}
+		out.Utterances[i] = u
+	}
+	sort.SliceStable(out.Utterances, func(i, j int) bool {
+		return out.Utterances[i].StartTime < out.Utterances[j].StartTime
+	})
+	return &out
+}
+
+// RescaleTime 把区间 [from0, from1] 中的时间 t 等比例映射到 [to0, to1]。原区间长度为 0 时映射到 to0。
+func RescaleTime(t, from0, from1, to0, to1 int64) int64 {
+	if from1 <= from0 {
+		return to0
+	}
+	t = min(max(t, from0), from1)
+	return to0 + (t-from0)*(to1-to0)/(from1-from0)
+}
+
+// ValidateCorrections 检查修正能否应用到结果上：句子存在、说话人存在、时间区间合法。
+func (r *Result) ValidateCorrections(corrections []Correction) error {
+	utterances := make(map[string]Utterance, len(r.Utterances))
+	for _, u := range r.Utterances {
+		if u.SentenceId != "" {
+			utterances[u.SentenceId] = u
+		}
+	}
+	for _, c := range corrections {
+		u, ok := utterances[c.SentenceId]
+		if !ok {
+			return gerror.Newf("句子不存在：%s", c.SentenceId)
+		}
+		if c.ChangesSpeaker() {
+			if _, ok = r.Speaker(*c.SpeakerId); !ok {
+				return gerror.Newf("句子 %s 的说话人不存在：%s", c.SentenceId, *c.SpeakerId)
+			}
+		}
+		start, end := u.StartTime, u.EndTime
+		if c.StartTime != nil {
+			start = *c.StartTime
+		}
+		if c.EndTime != nil {
+			end = *c.EndTime
+		}
+		if start < 0 || end < start {
+			return gerror.Newf("句子 %s 的时间区间非法 [%d, %d]", c.SentenceId, start, end)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package lark
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Decode 宽松地解析原始结果文件：
+//   - 数字字段接受数字或数字字符串；
+//   - 列表字段接受数组、单个对象，或包在已知键下的数组；
+//   - 缺失 / null 的文件和字段直接忽略。
+//
+// 只有文件存在但内容根本不是 JSON 对象或数组时才返回错误。语义校验请调用 Validate。
+func Decode(files Files) (*Result, error) {
+	r := &Result{}
+	var errs []string
+
+	if v, ok, err := root(files.AudioTranscription); err != nil {
+		errs = append(errs, "语音转写："+err.Error())
+	} else if ok {
+		r.Utterances, r.Speakers = decodeUtterances(v)
+	}
+	if v, ok, err := root(files.Chapter); err != nil {
+		errs = append(errs, "章节总结："+err.Error())
+	} else if ok {
+		r.Chapters = decodeChapters(v)
+	}
+	if v, ok, err := root(files.InformationExtraction); err != nil {
+		errs = append(errs, "信息提取："+err.Error())
+	} else if ok {
+		r.Todos, r.QuestionAnswers = decodeInformation(v)
+	}
+	if v, ok, err := root(files.Summarization); err != nil {
+		errs = append(errs, "全文总结："+err.Error())
+	} else if ok {
+		r.Summary = decodeSummary(v)
+	}
+	if v, ok, err := root(files.Translation); err != nil {
+		errs = append(errs, "翻译："+err.Error())
+	} else if ok {
+		r.Translations = decodeTranslations(v)
+	}
+
+	if len(errs) > 0 {
+		return r, gerror.Newf("结果文件格式无法识别：%s", strings.Join(errs, "；"))
+	}
+	return r, nil
+}
+
+// Validate 检查结果的语义一致性：时间区间合法、句子ID不重复、翻译能对应到转写句子。
+func (r *Result) Validate() error {
+	var problems []string
+	sentenceIds := make(map[string]struct{}, len(r.Utterances))
+	for i, u := range r.Utterances {
+		if u.StartTime < 0 || u.EndTime < u.StartTime {
+			problems = append(problems, fmt.Sprintf("第 %d 句时间区间非法 [%d, %d]", i, u.StartTime, u.EndTime))
+		}
+		if u.SentenceId != "" {
+			if _, ok := sentenceIds[u.SentenceId]; ok {
+				problems = append(problems, fmt.Sprintf("句子ID %s 重复", u.SentenceId))
+			}
+			sentenceIds[u.SentenceId] = struct{}{}
+		}
+		for _, w := range u.Words {
+			if w.EndTime < w.StartTime {
+				problems = append(problems, fmt.Sprintf("第 %d 句单词 %q 时间区间非法", i, w.Text))
+				break
+			}
+		}
+	}
+	for i, c := range r.Chapters {
+		if c.StartTime < 0 || c.EndTime < c.StartTime {
+			problems = append(problems, fmt.Sprintf("第 %d 个章节时间区间非法 [%d, %d]", i, c.StartTime, c.EndTime))
+		}
+	}
+	if len(sentenceIds) > 0 {
+		for _, t := range r.Translations {
+			if _, ok := sentenceIds[t.SentenceId]; t.SentenceId != "" && !ok {
+				problems = append(problems, fmt.Sprintf("翻译对应的句子ID %s 不存在", t.SentenceId))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	const maxProblems = 5
+	if len(problems) > maxProblems {
+		problems = append(problems[:maxProblems], fmt.Sprintf("等共 %d 个问题", len(problems)))
+	}
+	return gerror.Newf("结果校验失败：%s", strings.Join(problems, "；"))
+}
+
+func decodeUtterances(v any) ([]Utterance, []Speaker) {
+	var (
+		utterances []Utterance
+		speakers   []Speaker
+		seen       = map[string]struct{}{}
+	)
+	for _, m := range records(v, "utterances", "sentences", "result") {
+		u := Utterance{
+			SentenceId:  str(m, "sentence_id", "id"),
+			ParagraphId: str(m, "paragraph_id"),
+			ChannelId:   int(num(m, "channel_id")),
+			Lang:        str(m, "lang", "language"),
+			Content:     str(m, "content", "text"),
+			StartTime:   num(m, "start_time"),
+			EndTime:     num(m, "end_time"),
+		}
+		speaker := decodeSpeaker(m)
+		u.SpeakerId = speaker.Id
+		if speaker.Id != "" {
+			if _, ok := seen[speaker.Id]; !ok {
+				seen[speaker.Id] = struct{}{}
+				speakers = append(speakers, speaker)
+			}
+		}
+		for _, wm := range records(m["words"]) {
+			u.Words = append(u.Words, Word{
+				Text:      str(wm, "text", "content", "word"),
+				StartTime: num(wm, "start_time"),
+				EndTime:   num(wm, "end_time"),
+			})
+		}
+		utterances = append(utterances, u)
+	}
+	sort.SliceStable(utterances, func(i, j int) bool {
+		return utterances[i].StartTime < utterances[j].StartTime
+	})
+	return utterances, speakers
+}
+
+// decodeSpeaker 兼容 speaker 对象和扁平的 speaker_id / speaker_name 两种写法。
+func decodeSpeaker(m map[string]any) Speaker {
+	if sm, ok := m["speaker"].(map[string]any); ok {
+		return Speaker{
+			Id:   str(sm, "id"),
+			Name: str(sm, "name"),
+			Type: int(num(sm, "type")),
+		}
+	}
+	if s, ok := m["speaker"]; ok && s != nil {
+		return Speaker{Id: gconv.String(s)}
+	}
+	return Speaker{
+		Id:   str(m, "speaker_id"),
+		Name: str(m, "speaker_name"),
+	}
+}
+
+func decodeChapters(v any) []Chapter {
+	var chapters []Chapter
+	for _, m := range records(v, "chapter_summary", "chapters") {
+		chapters = append(chapters, Chapter{
+			Title:     str(m, "title"),
+			Summary:   str(m, "summary", "content", "paragraph"),
+			StartTime: num(m, "start_time"),
+			EndTime:   num(m, "end_time"),
+		})
+	}
+	return chapters
+}
+
+func decodeInformation(v any) ([]Todo, []QuestionAnswer) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	var (
+		todos []Todo
+		qas   []QuestionAnswer
+	)
+	for _, tm := range records(m["todo_list"]) {
+		todos = append(todos, Todo{
+			Content:  str(tm, "content", "todo", "text"),
+			Executor: str(tm, "executor", "assignee", "owner"),
+			Deadline: str(tm, "deadline", "due_time", "execution_time"),
+		})
+	}
+	for _, qm := range records(m["question_answer"]) {
+		qas = append(qas, QuestionAnswer{
+			Question: str(qm, "question"),
+			Answer:   str(qm, "answer"),
+		})
+	}
+	return todos, qas
+}
+
+func decodeSummary(v any) *Summary {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if inner, ok := m["summary"].(map[string]any); ok {
+		m = inner
+	}
+	s := &Summary{
+		Title:     str(m, "title"),
+		Paragraph: str(m, "paragraph", "summary", "content"),
+	}
+	if s.Title == "" && s.Paragraph == "" {
+		return nil
+	}
+	return s
+}
+
+func decodeTranslations(v any) []Translation {
+	var translations []Translation
+	for _, m := range records(v, "translations", "sentences", "result") {
+		translations = append(translations, Translation{
+			SentenceId:  str(m, "sentence_id", "id"),
+			SpeakerId:   decodeSpeaker(m).Id,
+			SourceLang:  str(m, "source_lang"),
+			TargetLang:  str(m, "target_lang"),
+			Content:     str(m, "content"),
+			Translation: str(m, "translation_content", "translation"),
+			StartTime:   num(m, "start_time"),
+			EndTime:     num(m, "end_time"),
+		})
+	}
+	sort.SliceStable(translations, func(i, j int) bool {
+		return translations[i].StartTime < translations[j].StartTime
+	})
+	return translations
+}
+
+// root 取出结果文件的根节点。文件不存在时 ok 为 false；内容不是对象或数组时返回错误。
+func root(j *gjson.Json) (v any, ok bool, err error) {
+	if j == nil || j.IsNil() {
+		return nil, false, nil
+	}
+	v = j.Interface()
+	// 数据库里偶尔会存成 JSON 字符串，再解一层
+	if s, isStr := v.(string); isStr {
+		if s = strings.TrimSpace(s); s == "" || s == "null" {
+			return nil, false, nil
+		}
+		if v, err = gjson.Decode(s); err != nil {
+			return nil, false, gerror.New("不是合法的 JSON")
+		}
+	}
+	switch v.(type) {
+	case nil:
+		return nil, false, nil
+	case map[string]any, []any:
+		return v, true, nil
+	default:
+		return nil, false, gerror.Newf("根节点类型 %T 不是对象或数组", v)
+	}
+}
+
+// records 把 v 展开为对象列表：数组逐个展开；对象如果包含 keys 中的某个键则展开该键，否则视为单个对象。
+func records(v any, keys ...string) []map[string]any {
+	switch t := v.(type) {
+	case []any:
+		out := make([]map[string]any, 0, len(t))
+		for _, item := range t {
+			if m, ok := item.(map[string]any); ok {
+				out = append(out, m)
+			}
+		}
+		return out
+	case map[string]any:
+		for _, k := range keys {
+			if inner, ok := t[k]; ok {
+				return records(inner)
+			}
+		}
+		if len(t) == 0 {
+			return nil
+		}
+		return []map[string]any{t}
+	default:
+		return nil
+	}
+}
+
+// str 返回 keys 中第一个非空字段的字符串值。
+func str(m map[string]any, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := m[k]; ok && v != nil {
+			if s := strings.TrimSpace(gconv.String(v)); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// num 返回 keys 中第一个存在字段的整数值，兼容数字字符串。
+func num(m map[string]any, keys ...string) int64 {
+	for _, k := range keys {
+		if v, ok := m[k]; ok && v != nil {
+			return gconv.Int64(v)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,118 @@
+// Package lark 定义豆包妙记（volc.lark.minutes）结果文件的类型化模型。
+//
+// 火山云返回的五个结果文件（语音转写、章节总结、信息提取、全文总结、翻译）原样保存在 transcription 表中，
+// 字段类型、包裹方式在不同版本之间并不稳定（数字可能是字符串，数组可能被包在对象里，words 可能为 null）。
+// Decode 以宽松的方式把它们解析为统一的 Result，Validate 再做语义校验。
+package lark
+
+import (
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// Files 是火山云返回的原始结果文件，任意一个都可以为空。
+type Files struct {
+	AudioTranscription    *gjson.Json
+	Chapter               *gjson.Json
+	InformationExtraction *gjson.Json
+	Summarization         *gjson.Json
+	Translation           *gjson.Json
+}
+
+// Result 是一个任务的完整类型化结果。时间单位均为毫秒。
+type Result struct {
+	Utterances      []Utterance      `json:"utterances" dc:"转写句子，按开始时间排序"`
+	Speakers        []Speaker        `json:"speakers" dc:"说话人，按首次发言顺序排列"`
+	Chapters        []Chapter        `json:"chapters" dc:"章节总结"`
+	Todos           []Todo           `json:"todos" dc:"待办事项"`
+	QuestionAnswers []QuestionAnswer `json:"questionAnswers" dc:"问答"`
+	Summary         *Summary         `json:"summary" dc:"全文总结，未开启时为 null"`
+	Translations    []Translation    `json:"translations" dc:"逐句翻译"`
+}
+
+// Speaker 是说话人信息。
+type Speaker struct {
+	Id      string `json:"id" dc:"说话人ID"`
+	Name    string `json:"name" dc:"说话人名称"`
+	Type    int    `json:"type" dc:"说话人类型"`
+	Profile string `json:"profile,omitempty" dc:"关联的用户 UPN，由说话人映射设置"`
+}
+
+// Word 是单词级时间戳，只有提交任务时开启 NeedWordTimeSeries 才会有。
+type Word struct {
+	Text      string `json:"text" dc:"单词"`
+	StartTime int64  `json:"startTime" dc:"开始时间（毫秒）"`
+	EndTime   int64  `json:"endTime" dc:"结束时间（毫秒）"`
+}
+
+// Utterance 是一句转写结果。
+type Utterance struct {
+	SentenceId  string `json:"sentenceId" dc:"句子ID"`
+	ParagraphId string `json:"paragraphId" dc:"段落ID"`
+	ChannelId   int    `json:"channelId" dc:"声道"`
+	SpeakerId   string `json:"speakerId" dc:"说话人ID，对应 speakers[].id，未开启说话人识别时为空"`
+	Lang        string `json:"lang" dc:"语种"`
+	Content     string `json:"content" dc:"文本"`
+	StartTime   int64  `json:"startTime" dc:"开始时间（毫秒）"`
+	EndTime     int64  `json:"endTime" dc:"结束时间（毫秒）"`
+	Words       []Word `json:"words" dc:"单词时间序列"`
+}
+
+// Chapter 是一个章节总结。
+type Chapter struct {
+	Title     string `json:"title" dc:"章节标题"`
+	Summary   string `json:"summary" dc:"章节摘要"`
+	StartTime int64  `json:"startTime" dc:"开始时间（毫秒）"`
+	EndTime   int64  `json:"endTime" dc:"结束时间（毫秒）"`
+}
+
+// Todo 是一条待办事项。
+type Todo struct {
+	Content  string `json:"content" dc:"待办内容"`
+	Executor string `json:"executor" dc:"执行人"`
+	Deadline string `json:"deadline" dc:"截止时间（原文）"`
+}
+
+// QuestionAnswer 是一组问答。
+type QuestionAnswer struct {
+	Question string `json:"question" dc:"问题"`
+	Answer   string `json:"answer" dc:"回答"`
+}
+
+// Summary 是全文总结。
+type Summary struct {
+	Title     string `json:"title" dc:"标题"`
+	Paragraph string `json:"paragraph" dc:"总结正文"`
+}
+
+// Translation 是一句转写对应的翻译。
+type Translation struct {
+	SentenceId  string `json:"sentenceId" dc:"句子ID，对应 utterances[].sentenceId"`
+	SpeakerId   string `json:"speakerId" dc:"说话人ID"`
+	SourceLang  string `json:"sourceLang" dc:"原文语种"`
+	TargetLang  string `json:"targetLang" dc:"译文语种"`
+	Content     string `json:"content" dc:"原文"`
+	Translation string `json:"translation" dc:"译文"`
+	StartTime   int64  `json:"startTime" dc:"开始时间（毫秒）"`
+	EndTime     int64  `json:"endTime" dc:"结束时间（毫秒）"`
+}
+
+// Duration 返回最后一句转写的结束时间（毫秒），没有转写时为 0。
+func (r *Result) Duration() int64 {
+	var duration int64
+	for _, u := range r.Utterances {
+		if u.EndTime > duration {
+			duration = u.EndTime
+		}
+	}
+	return duration
+}
+
+// Speaker 按 ID 查找说话人。
+func (r *Result) Speaker(id string) (Speaker, bool) {
+	for _, s := range r.Speakers {
+		if s.Id == id {
+			return s, true
+		}
+	}
+	return Speaker{}, false
+}
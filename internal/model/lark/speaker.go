@@ -0,0 +1,62 @@
+package lark
+
+// SpeakerProfile 是用户对某个说话人的修正：改名、关联用户，或合并到另一个说话人。
+type SpeakerProfile struct {
+	SpeakerId string // 上游说话人ID
+	Name      string // 显示名称，为空时保留上游名称
+	Profile   string // 关联的用户 UPN
+	MergeInto string // 合并到的说话人ID，为空表示不合并
+}
+
+// maxMergeDepth 限制合并链的长度，防止错误数据形成环。
+const maxMergeDepth = 8
+
+// ResolveSpeaker 沿合并链找到说话人最终对应的ID。
+func ResolveSpeaker(profiles map[string]SpeakerProfile, id string) string {
+	for i := 0; i < maxMergeDepth; i++ {
+		p, ok := profiles[id]
+		if !ok || p.MergeInto == "" || p.MergeInto == id {
+			return id
+		}
+		id = p.MergeInto
+	}
+	return id
+}
+
+// WithSpeakers 返回应用了说话人映射的结果副本，原结果不变：
+// 被合并的说话人的句子和翻译改为目标说话人，并从说话人列表中移除；其余说话人按映射改名。
+func (r *Result) WithSpeakers(profiles []SpeakerProfile) *Result {
+	if r == nil || len(profiles) == 0 {
+		return r
+	}
+	byId := make(map[string]SpeakerProfile, len(profiles))
+	for _, p := range profiles {
+		byId[p.SpeakerId] = p
+	}
+
+	out := *r
+	out.Speakers = make([]Speaker, 0, len(r.Speakers))
+	for _, s := range r.Speakers {
+		if ResolveSpeaker(byId, s.Id) != s.Id {
+			continue
+		}
+		if p, ok := byId[s.Id]; ok {
+			if p.Name != "" {
+				s.Name = p.Name
+			}
+			s.Profile = p.Profile
+		}
+		out.Speakers = append(out.Speakers, s)
+	}
+	out.Utterances = make([]Utterance, len(r.Utterances))
+	for i, u := range r.Utterances {
+		u.SpeakerId = ResolveSpeaker(byId, u.SpeakerId)
+		out.Utterances[i] = u
+	}
+	out.Translations = make([]Translation, len(r.Translations))
+	for i, t := range r.Translations {
+		t.SpeakerId = ResolveSpeaker(byId, t.SpeakerId)
+		out.Translations[i] = t
+	}
+	return &out
+}
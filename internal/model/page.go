@@ -0,0 +1,10 @@
+package model
+
+// PageInfo 是列表接口共用的分页信息，由 pagination.Page 转换得到。
+type PageInfo struct {
+	HasMore        bool   `json:"hasMore" dc:"是否还有下一页"`
+	NextCursor     string `json:"nextCursor" dc:"下一页游标，没有下一页时为空"`
+	PrevCursor     string `json:"prevCursor" dc:"上一页游标，当前是第一页时为空"`
+	Total          int    `json:"total" dc:"总条目数，total=none 时为 0"`
+	TotalEstimated bool   `json:"totalEstimated" dc:"total 是否为估算值（实际总数不少于 total）"`
+}
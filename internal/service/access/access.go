@@ -0,0 +1,179 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"doubao-speech-service/internal/dao"
+)
+
+// Role 表示当前用户对某个任务的权限级别，数值越大权限越高。
+type Role int
+
+const (
+	RoleNone   Role = iota // 无权限
+	RoleViewer             // 只读：查看任务详情、转写结果、文件
+	RoleEditor             // 可编辑：修改任务元数据、转写内容等
+	RoleOwner              // 拥有者：管理共享、删除任务
+)
+
+// 共享记录中 role 字段的取值
+const (
+	ShareRoleViewer = "viewer"
+	ShareRoleEditor = "editor"
+)
+
+// 共享记录中 grantee_type 字段的取值
+const (
+	GranteeUser  = "user"
+	GranteeGroup = "group"
+)
+
+// ParseShareRole 把共享记录中的 role 字符串转换为 Role。
+func ParseShareRole(role string) Role {
+	switch role {
+	case ShareRoleEditor:
+		return RoleEditor
+	case ShareRoleViewer:
+		return RoleViewer
+	default:
+		return RoleNone
+	}
+}
+
+// User 当前请求的调用者。身份由上游网关通过请求头注入。
+type User struct {
+	ID     string   // X-User-ID
+	Groups []string // X-User-Groups，逗号分隔
+}
+
+// CurrentUser 从请求上下文中读取调用者身份。
+func CurrentUser(ctx context.Context) User {
+	r := g.RequestFromCtx(ctx)
+	if r == nil {
+		return User{}
+	}
+	user := User{ID: r.Header.Get("X-User-ID")}
+	for _, group := range strings.Split(r.Header.Get("X-User-Groups"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			user.Groups = append(user.Groups, group)
+		}
+	}
+	return user
+}
+
+// TaskRole 计算用户对任务的权限：拥有者、团队空间成员角色、共享权限三者取最高。
+// 任务不存在时返回 CodeNotFound 错误。
+func TaskRole(ctx context.Context, requestId string, user User) (Role, error) {
+	var task struct {
+		Owner       string `json:"owner"`
+		WorkspaceId string `json:"workspace_id"`
+	}
+	cols := dao.Transcription.Columns()
+	if err := dao.Transcription.Ctx(ctx).
+		Fields(cols.Owner, cols.WorkspaceId).
+		Where(cols.RequestId+" = ?", requestId).
+		Limit(1).
+		Scan(&task); err != nil {
+		return RoleNone, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询任务记录失败")
+	}
+	if task.Owner == "" {
+		return RoleNone, gerror.NewCodef(gcode.CodeNotFound, "任务不存在：%s", requestId)
+	}
+	if user.ID != "" && task.Owner == user.ID {
+		return RoleOwner, nil
+	}
+
+	best := RoleNone
+	if task.WorkspaceId != "" {
+		wsRole, err := memberRole(ctx, task.WorkspaceId, user)
+		if err != nil {
+			return RoleNone, err
+		}
+		best = wsRole.TaskRole()
+	}
+	if best == RoleOwner {
+		return best, nil
+	}
+	if role, err := shareRole(ctx, requestId, user); err != nil {
+		return RoleNone, err
+	} else if role > best {
+		best = role
+	}
+	return best, nil
+}
+
+// VisibleCondition 返回 transcription 表中用户可见任务的查询条件及参数：
+// 自己的个人任务、所在团队空间的任务、共享给自己的任务。
+func VisibleCondition(ctx context.Context, user User) (string, []any) {
+	cols := dao.Transcription.Columns()
+	return fmt.Sprintf("(%s = ? OR %s IN ? OR %s IN ?)", cols.Owner, cols.WorkspaceId, cols.RequestId),
+		[]any{user.ID, MemberWorkspaceIds(ctx, user), SharedRequestIds(ctx, user)}
+}
+
+// shareRole 查询任务共享给用户（本人或其所在用户组）的最高权限。
+func shareRole(ctx context.Context, requestId string, user User) (Role, error) {
+	if user.ID == "" && len(user.Groups) == 0 {
+		return RoleNone, nil
+	}
+	cols := dao.TranscriptionShare.Columns()
+	condition, args := GranteeCondition(user)
+	roles, err := dao.TranscriptionShare.Ctx(ctx).
+		Fields(cols.Role).
+		Where(cols.RequestId+" = ?", requestId).
+		Where(condition, args...).
+		Array()
+	if err != nil {
+		return RoleNone, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询共享记录失败")
+	}
+	best := RoleNone
+	for _, role := range roles {
+		if r := ParseShareRole(role.String()); r > best {
+			best = r
+		}
+	}
+	return best, nil
+}
+
+// GranteeCondition 返回匹配用户本人或其所在用户组的 transcription_share 查询条件及参数。
+func GranteeCondition(user User) (string, []any) {
+	cols := dao.TranscriptionShare.Columns()
+	condition := fmt.Sprintf("(%s = ? AND %s = ?)", cols.GranteeType, cols.Grantee)
+	args := []any{GranteeUser, user.ID}
+	if len(user.Groups) > 0 {
+		condition += fmt.Sprintf(" OR (%s = ? AND %s IN (?))", cols.GranteeType, cols.Grantee)
+		args = append(args, GranteeGroup, user.Groups)
+	}
+	return "(" + condition + ")", args
+}
+
+// SharedRequestIds 返回共享给用户的任务 request_id 子查询，用法：Where("request_id IN ?", SharedRequestIds(ctx, user))。
+func SharedRequestIds(ctx context.Context, user User) *gdb.Model {
+	cols := dao.TranscriptionShare.Columns()
+	condition, args := GranteeCondition(user)
+	return dao.TranscriptionShare.Ctx(ctx).
+		Fields(cols.RequestId).
+		Where(condition, args...)
+}
+
+// Require 校验用户对任务至少拥有 need 权限，返回实际权限。
+func Require(ctx context.Context, requestId string, user User, need Role) (Role, error) {
+	role, err := TaskRole(ctx, requestId, user)
+	if err != nil {
+		return RoleNone, err
+	}
+	if role < need {
+		// 对没有任何权限的用户隐藏任务是否存在
+		if role == RoleNone {
+			return RoleNone, gerror.NewCodef(gcode.CodeNotFound, "任务不存在：%s", requestId)
+		}
+		return role, gerror.NewCode(gcode.CodeNotAuthorized, "没有操作该任务的权限")
+	}
+	return role, nil
+}
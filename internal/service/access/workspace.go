@@ -0,0 +1,108 @@
+package access
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"doubao-speech-service/internal/dao"
+)
+
+// WorkspaceRole 表示用户在团队空间中的角色，数值越大权限越高。
+type WorkspaceRole int
+
+const (
+	WorkspaceRoleNone   WorkspaceRole = iota // 非成员
+	WorkspaceRoleViewer                      // 查看空间内的任务
+	WorkspaceRoleEditor                      // 上传、编辑空间内的任务
+	WorkspaceRoleAdmin                       // 管理成员，管理空间内的所有任务
+	WorkspaceRoleOwner                       // 管理空间本身
+)
+
+var workspaceRoleNames = map[WorkspaceRole]string{
+	WorkspaceRoleViewer: "viewer",
+	WorkspaceRoleEditor: "editor",
+	WorkspaceRoleAdmin:  "admin",
+	WorkspaceRoleOwner:  "owner",
+}
+
+func (r WorkspaceRole) String() string {
+	return workspaceRoleNames[r]
+}
+
+// ParseWorkspaceRole 把 workspace_member 中的 role 字符串转换为 WorkspaceRole。
+func ParseWorkspaceRole(role string) WorkspaceRole {
+	for r, name := range workspaceRoleNames {
+		if name == role {
+			return r
+		}
+	}
+	return WorkspaceRoleNone
+}
+
+// TaskRole 返回空间角色对空间内任务的权限。
+func (r WorkspaceRole) TaskRole() Role {
+	switch r {
+	case WorkspaceRoleOwner, WorkspaceRoleAdmin:
+		return RoleOwner
+	case WorkspaceRoleEditor:
+		return RoleEditor
+	case WorkspaceRoleViewer:
+		return RoleViewer
+	default:
+		return RoleNone
+	}
+}
+
+// GetWorkspaceRole 查询用户在团队空间中的角色。空间不存在时返回 CodeNotFound 错误。
+func GetWorkspaceRole(ctx context.Context, workspaceId string, user User) (WorkspaceRole, error) {
+	wsCols := dao.Workspace.Columns()
+	if exist, err := dao.Workspace.Ctx(ctx).Where(wsCols.WorkspaceId+" = ?", workspaceId).Exist(); err != nil {
+		return WorkspaceRoleNone, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询团队空间失败")
+	} else if !exist {
+		return WorkspaceRoleNone, gerror.NewCodef(gcode.CodeNotFound, "团队空间不存在：%s", workspaceId)
+	}
+	return memberRole(ctx, workspaceId, user)
+}
+
+// memberRole 查询用户在空间中的角色，不检查空间是否存在。
+func memberRole(ctx context.Context, workspaceId string, user User) (WorkspaceRole, error) {
+	if user.ID == "" {
+		return WorkspaceRoleNone, nil
+	}
+	cols := dao.WorkspaceMember.Columns()
+	role, err := dao.WorkspaceMember.Ctx(ctx).
+		Fields(cols.Role).
+		Where(cols.WorkspaceId+" = ?", workspaceId).
+		Where(cols.Member+" = ?", user.ID).
+		Value()
+	if err != nil {
+		return WorkspaceRoleNone, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询团队空间成员失败")
+	}
+	return ParseWorkspaceRole(role.String()), nil
+}
+
+// RequireWorkspace 校验用户在团队空间中至少拥有 need 角色，返回实际角色。
+func RequireWorkspace(ctx context.Context, workspaceId string, user User, need WorkspaceRole) (WorkspaceRole, error) {
+	role, err := GetWorkspaceRole(ctx, workspaceId, user)
+	if err != nil {
+		return WorkspaceRoleNone, err
+	}
+	if role < need {
+		if role == WorkspaceRoleNone {
+			return WorkspaceRoleNone, gerror.NewCodef(gcode.CodeNotFound, "团队空间不存在：%s", workspaceId)
+		}
+		return role, gerror.NewCode(gcode.CodeNotAuthorized, "没有操作该团队空间的权限")
+	}
+	return role, nil
+}
+
+// MemberWorkspaceIds 返回用户所在团队空间的 workspace_id 子查询，用法：Where("workspace_id IN ?", MemberWorkspaceIds(ctx, user))。
+func MemberWorkspaceIds(ctx context.Context, user User) *gdb.Model {
+	cols := dao.WorkspaceMember.Columns()
+	return dao.WorkspaceMember.Ctx(ctx).
+		Fields(cols.WorkspaceId).
+		Where(cols.Member+" = ?", user.ID)
+}
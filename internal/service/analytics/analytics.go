@@ -0,0 +1,221 @@
+// Package analytics 从转写结果计算会议的对话统计：发言时长、轮次、语速、最长独白、抢话次数、静默占比和发言时间线。
+//
+// 统计在任务成功时计算一次并缓存在 transcription_result 表中，转写修正或说话人映射变化后重新计算。
+package analytics
+
+import (
+	"sort"
+	"unicode"
+
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/export"
+)
+
+// Analytics 是一个任务的对话统计。时间单位均为毫秒。
+type Analytics struct {
+	Duration            int64          `json:"duration" dc:"会议时长"`
+	TalkTime            int64          `json:"talkTime" dc:"有人发言的总时长（重叠部分只计一次）"`
+	SilenceTime         int64          `json:"silenceTime" dc:"静默时长"`
+	SilenceRatio        float64        `json:"silenceRatio" dc:"静默占比（0~1）"`
+	Utterances          int            `json:"utterances" dc:"句数"`
+	Turns               int            `json:"turns" dc:"发言轮次，说话人切换一次计一轮"`
+	Interruptions       int            `json:"interruptions" dc:"抢话次数：在他人尚未说完时开始发言"`
+	Characters          int            `json:"characters" dc:"字数（不含空白和标点）"`
+	Words               int            `json:"words" dc:"词数：每个汉字计一个词，连续的字母数字计一个词"`
+	CharactersPerMinute float64        `json:"charactersPerMinute" dc:"每分钟发言字数，按发言时长计算"`
+	WordsPerMinute      float64        `json:"wordsPerMinute" dc:"每分钟发言词数，按发言时长计算"`
+	LongestMonologue    *Turn          `json:"longestMonologue" dc:"最长的一轮发言，没有转写时为 null"`
+	Speakers            []SpeakerStats `json:"speakers" dc:"各说话人统计，按发言时长倒序"`
+	Timeline            []Turn         `json:"timeline" dc:"发言时间线，每一轮发言一条，按开始时间排序"`
+}
+
+// SpeakerStats 是单个说话人的统计。
+type SpeakerStats struct {
+	SpeakerId           string  `json:"speakerId" dc:"说话人ID"`
+	Speaker             string  `json:"speaker" dc:"说话人名称"`
+	TalkTime            int64   `json:"talkTime" dc:"发言时长"`
+	Share               float64 `json:"share" dc:"发言时长占所有人发言时长之和的比例（0~1）"`
+	Utterances          int     `json:"utterances" dc:"句数"`
+	Turns               int     `json:"turns" dc:"发言轮次"`
+	Characters          int     `json:"characters" dc:"字数"`
+	Words               int     `json:"words" dc:"词数"`
+	CharactersPerMinute float64 `json:"charactersPerMinute" dc:"每分钟字数"`
+	WordsPerMinute      float64 `json:"wordsPerMinute" dc:"每分钟词数"`
+	LongestMonologue    int64   `json:"longestMonologue" dc:"最长一轮发言的时长"`
+	Interruptions       int     `json:"interruptions" dc:"抢话次数"`
+	Interrupted         int     `json:"interrupted" dc:"被抢话次数"`
+}
+
+// Turn 是同一说话人连续的一轮发言。
+type Turn struct {
+	SpeakerId  string `json:"speakerId" dc:"说话人ID"`
+	Speaker    string `json:"speaker" dc:"说话人名称"`
+	StartTime  int64  `json:"startTime" dc:"开始时间"`
+	EndTime    int64  `json:"endTime" dc:"结束时间"`
+	Utterances int    `json:"utterances" dc:"句数"`
+}
+
+// Compute 计算统计。duration 为媒体时长，<= 0 时使用最后一句的结束时间。
+func Compute(result *lark.Result, duration int64) *Analytics {
+	a := &Analytics{Speakers: []SpeakerStats{}, Timeline: []Turn{}}
+	if result == nil {
+		return a
+	}
+	utterances := make([]lark.Utterance, 0, len(result.Utterances))
+	for _, u := range result.Utterances {
+		if u.EndTime > u.StartTime {
+			utterances = append(utterances, u)
+		}
+	}
+	sort.SliceStable(utterances, func(i, j int) bool {
+		return utterances[i].StartTime < utterances[j].StartTime
+	})
+	if duration <= 0 {
+		duration = result.Duration()
+	}
+	a.Duration = duration
+	a.Utterances = len(utterances)
+
+	var (
+		stats = map[string]*SpeakerStats{}
+		order []string
+		// ends 记录每个说话人当前这句话的结束时间，用于判断抢话
+		ends = map[string]int64{}
+		// covered 是已统计到的发言区间的最右端，用于计算重叠只计一次的发言时长
+		covered int64
+	)
+	stat := func(id string) *SpeakerStats {
+		s, ok := stats[id]
+		if !ok {
+			s = &SpeakerStats{SpeakerId: id, Speaker: export.SpeakerName(result, id)}
+			stats[id] = s
+			order = append(order, id)
+		}
+		return s
+	}
+
+	for _, u := range utterances {
+		s := stat(u.SpeakerId)
+		length := u.EndTime - u.StartTime
+		chars, words := countText(u.Content)
+		if len(u.Words) > 0 {
+			words = len(u.Words)
+		}
+		s.Utterances++
+		s.TalkTime += length
+		s.Characters += chars
+		s.Words += words
+		a.Characters += chars
+		a.Words += words
+
+		// 在他人尚未说完时开始发言记为一次抢话，同一句只计一次，被抢话的一方取最晚结束的那位
+		var (
+			interrupted string
+			latest      int64
+		)
+		for id, end := range ends {
+			if id != u.SpeakerId && end > u.StartTime && (end > latest || (end == latest && id < interrupted)) {
+				interrupted, latest = id, end
+			}
+		}
+		if interrupted != "" {
+			s.Interruptions++
+			stats[interrupted].Interrupted++
+			a.Interruptions++
+		}
+		ends[u.SpeakerId] = max(ends[u.SpeakerId], u.EndTime)
+
+		if u.EndTime > covered {
+			a.TalkTime += u.EndTime - max(u.StartTime, covered)
+			covered = u.EndTime
+		}
+
+		if n := len(a.Timeline); n > 0 && a.Timeline[n-1].SpeakerId == u.SpeakerId {
+			turn := &a.Timeline[n-1]
+			turn.EndTime = max(turn.EndTime, u.EndTime)
+			turn.Utterances++
+		} else {
+			a.Timeline = append(a.Timeline, Turn{
+				SpeakerId:  u.SpeakerId,
+				Speaker:    s.Speaker,
+				StartTime:  u.StartTime,
+				EndTime:    u.EndTime,
+				Utterances: 1,
+			})
+			s.Turns++
+		}
+	}
+	a.Turns = len(a.Timeline)
+
+	for i, turn := range a.Timeline {
+		length := turn.EndTime - turn.StartTime
+		s := stats[turn.SpeakerId]
+		s.LongestMonologue = max(s.LongestMonologue, length)
+		if a.LongestMonologue == nil || length > a.LongestMonologue.EndTime-a.LongestMonologue.StartTime {
+			a.LongestMonologue = &a.Timeline[i]
+		}
+	}
+	if a.LongestMonologue != nil {
+		longest := *a.LongestMonologue
+		a.LongestMonologue = &longest
+	}
+
+	var total int64
+	for _, id := range order {
+		total += stats[id].TalkTime
+	}
+	for _, id := range order {
+		s := stats[id]
+		if total > 0 {
+			s.Share = float64(s.TalkTime) / float64(total)
+		}
+		s.CharactersPerMinute = perMinute(s.Characters, s.TalkTime)
+		s.WordsPerMinute = perMinute(s.Words, s.TalkTime)
+		a.Speakers = append(a.Speakers, *s)
+	}
+	sort.SliceStable(a.Speakers, func(i, j int) bool {
+		return a.Speakers[i].TalkTime > a.Speakers[j].TalkTime
+	})
+
+	a.CharactersPerMinute = perMinute(a.Characters, a.TalkTime)
+	a.WordsPerMinute = perMinute(a.Words, a.TalkTime)
+	if a.Duration < a.TalkTime {
+		a.Duration = a.TalkTime
+	}
+	a.SilenceTime = a.Duration - a.TalkTime
+	if a.Duration > 0 {
+		a.SilenceRatio = float64(a.SilenceTime) / float64(a.Duration)
+	}
+	return a
+}
+
+// countText 统计字数和词数：字数不含空白和标点；每个汉字（及其他表意文字）计一个词，连续的字母数字计一个词。
+func countText(text string) (chars, words int) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+			chars++
+			words++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			chars++
+			if !inWord {
+				words++
+			}
+			inWord = true
+		case r == '\'' && inWord:
+			// 英文缩写 don't 算一个词
+		default:
+			inWord = false
+		}
+	}
+	return chars, words
+}
+
+func perMinute(n int, ms int64) float64 {
+	if ms <= 0 {
+		return 0
+	}
+	return float64(n) * 60000 / float64(ms)
+}
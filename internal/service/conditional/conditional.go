@@ -0,0 +1,73 @@
+// Package conditional 实现基于 ETag 的条件请求：响应带上 ETag，请求的 If-None-Match 与之匹配时返回 304。
+//
+// ETag 是强校验值，对应未压缩的响应内容。压缩中间件会在 ETag 后加上编码后缀（例如 "abc-br"），
+// 比较时去掉后缀，因此客户端缓存的压缩版本和未压缩版本都能命中。
+package conditional
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// Tag 由若干部分计算一个强 ETag（带引号）。
+func Tag(parts ...any) string {
+	h := sha256.New()
+	for _, p := range parts {
+		_, _ = fmt.Fprintf(h, "%v\x00", p)
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// Encoded 返回压缩后的响应使用的 ETag。弱 ETag 原样返回。
+func Encoded(tag, encoding string) string {
+	if !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) || len(tag) < 2 {
+		return tag
+	}
+	return tag[:len(tag)-1] + "-" + encoding + `"`
+}
+
+// Match 判断 If-None-Match 是否与 tag 匹配。按弱比较：忽略 W/ 前缀和压缩编码后缀。
+func Match(ifNoneMatch, tag string) bool {
+	if ifNoneMatch == "" || tag == "" {
+		return false
+	}
+	want := opaque(tag)
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || opaque(candidate) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// encodings 是压缩中间件可能加在 ETag 后的编码后缀
+var encodings = []string{"br", "zstd", "gzip", "deflate"}
+
+func opaque(tag string) string {
+	tag = strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+	for _, encoding := range encodings {
+		if s, ok := strings.CutSuffix(tag, "-"+encoding); ok {
+			return s
+		}
+	}
+	return tag
+}
+
+// Check 给响应设置 ETag，并返回请求的 If-None-Match 是否与之匹配。
+// 匹配时处理函数可以直接返回，由 middlewares.ConditionalMiddleware 输出 304。
+func Check(ctx context.Context, tag string) bool {
+	r := g.RequestFromCtx(ctx)
+	if r == nil || tag == "" {
+		return false
+	}
+	r.Response.Header().Set("ETag", tag)
+	// 响应因用户而异，不允许共享缓存；浏览器每次使用前都需要重新校验
+	r.Response.Header().Set("Cache-Control", "private, no-cache")
+	return Match(r.Header.Get("If-None-Match"), tag)
+}
@@ -0,0 +1,157 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Docx 是一个最小化的 Word 文档生成器，只支持导出需要的标题、段落等元素。
+type Docx struct {
+	body strings.Builder
+}
+
+func NewDocx() *Docx {
+	return &Docx{}
+}
+
+// Heading 添加 1~3 级标题。
+func (d *Docx) Heading(level int, text string) {
+	if level < 1 {
+		level = 1
+	}
+	if level > 3 {
+		level = 3
+	}
+	fmt.Fprintf(&d.body, `<w:p><w:pPr><w:pStyle w:val="Heading%d"/></w:pPr>%s</w:p>`, level, run(text, false))
+}
+
+// Paragraph 添加普通段落，文本中的换行会保留。
+func (d *Docx) Paragraph(text string) {
+	fmt.Fprintf(&d.body, `<w:p>%s</w:p>`, run(text, false))
+}
+
+// Label 添加加粗的单行段落，例如说话人和时间。
+func (d *Docx) Label(text string) {
+	fmt.Fprintf(&d.body, `<w:p><w:pPr><w:spacing w:before="120" w:after="0"/></w:pPr>%s</w:p>`, run(text, true))
+}
+
+// Run 是一段带格式的文本。
+type Run struct {
+	Text string
+	Bold bool
+}
+
+// RichParagraph 添加由多段格式不同的文本组成的段落。
+func (d *Docx) RichParagraph(runs ...Run) {
+	d.body.WriteString("<w:p>")
+	for _, r := range runs {
+		d.body.WriteString(run(r.Text, r.Bold))
+	}
+	d.body.WriteString("</w:p>")
+}
+
+// Bullet 添加一个列表项。为了不依赖 numbering.xml，使用缩进加圆点字符实现。
+func (d *Docx) Bullet(runs ...Run) {
+	d.body.WriteString(`<w:p><w:pPr><w:spacing w:after="60"/><w:ind w:left="420" w:hanging="210"/></w:pPr>`)
+	d.body.WriteString(run("• ", false))
+	for _, r := range runs {
+		d.body.WriteString(run(r.Text, r.Bold))
+	}
+	d.body.WriteString("</w:p>")
+}
+
+// Table 添加带边框的表格，第一行作为加粗的表头。
+func (d *Docx) Table(rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	d.body.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="5000" w:type="pct"/>` +
+		`<w:tblBorders><w:top w:val="single" w:sz="4"/><w:left w:val="single" w:sz="4"/><w:bottom w:val="single" w:sz="4"/>` +
+		`<w:right w:val="single" w:sz="4"/><w:insideH w:val="single" w:sz="4"/><w:insideV w:val="single" w:sz="4"/></w:tblBorders></w:tblPr>`)
+	for i, row := range rows {
+		d.body.WriteString("<w:tr>")
+		if i == 0 {
+			d.body.WriteString(`<w:trPr><w:tblHeader/></w:trPr>`)
+		}
+		for _, cell := range row {
+			fmt.Fprintf(&d.body, `<w:tc><w:p>%s</w:p></w:tc>`, run(cell, i == 0))
+		}
+		d.body.WriteString("</w:tr>")
+	}
+	// Word 要求表格后面至少跟一个段落
+	d.body.WriteString("</w:tbl><w:p/>")
+}
+
+// Bytes 打包生成 .docx 文件内容。
+func (d *Docx) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRels},
+		{"word/styles.xml", docxStyles},
+		{"word/document.xml", docxDocumentHead + d.body.String() + docxDocumentTail},
+	}
+	for _, p := range parts {
+		w, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write([]byte(p.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// run 生成一个文本 run，换行转换为 <w:br/>。
+func run(text string, bold bool) string {
+	var b strings.Builder
+	b.WriteString("<w:r>")
+	if bold {
+		b.WriteString("<w:rPr><w:b/></w:rPr>")
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			b.WriteString("<w:br/>")
+		}
+		b.WriteString(`<w:t xml:space="preserve">`)
+		_ = xml.EscapeText(&b, []byte(line))
+		b.WriteString("</w:t>")
+	}
+	b.WriteString("</w:r>")
+	return b.String()
+}
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+</Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+const docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:eastAsia="Microsoft YaHei"/><w:sz w:val="22"/></w:rPr></w:rPrDefault></w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:pPr><w:spacing w:after="120"/></w:pPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:before="240" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="36"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:before="200" w:after="100"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="30"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading3"><w:name w:val="heading 3"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:before="160" w:after="80"/><w:outlineLvl w:val="2"/></w:pPr><w:rPr><w:b/><w:sz w:val="26"/></w:rPr></w:style>
+</w:styles>`
+
+const docxDocumentHead = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`
+
+const docxDocumentTail = `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="720" w:footer="720" w:gutter="0"/></w:sectPr></w:body></w:document>`
@@ -0,0 +1,268 @@
+// Package export 把类型化的任务结果渲染为可下载的文件（字幕、纯文本、Markdown、DOCX 等）。
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/model/lark"
+)
+
+// 支持的导出格式
+const (
+	FormatSRT  = "srt"
+	FormatVTT  = "vtt"
+	FormatTXT  = "txt"
+	FormatMD   = "md"
+	FormatDOCX = "docx"
+	FormatJSON = "json"
+	FormatHTML = "html"
+)
+
+var contentTypes = map[string]string{
+	FormatSRT:  "application/x-subrip; charset=utf-8",
+	FormatVTT:  "text/vtt; charset=utf-8",
+	FormatTXT:  "text/plain; charset=utf-8",
+	FormatMD:   "text/markdown; charset=utf-8",
+	FormatDOCX: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	FormatJSON: "application/json; charset=utf-8",
+	FormatHTML: "text/html; charset=utf-8",
+}
+
+// 导出模式，决定输出原文、译文还是双语
+const (
+	ModeSource    = "source"    // 只输出原文
+	ModeBilingual = "bilingual" // 字幕原文在上译文在下，md / docx 为双栏表格
+	ModeTarget    = "target"    // 只输出译文
+)
+
+// Options 控制转写内容的渲染方式。
+type Options struct {
+	Mode          string // 导出模式，为空时等同于 ModeSource
+	SpeakerLabels bool   // 是否输出说话人名称
+	Timestamps    bool   // 文本类格式（txt / md / docx）是否输出时间戳，字幕格式总是带时间轴
+	MaxLineLength int    // 字幕每行最多字符数，0 表示不换行
+	MergeSpeaker  bool   // 是否合并同一说话人连续的句子
+}
+
+// Meta 是导出文件中用到的任务元数据。
+type Meta struct {
+	RequestId string
+	Title     string // 任务标题，为空时使用文件名
+	FileName  string
+	CreatedAt *gtime.Time
+}
+
+// DisplayTitle 返回用于文档标题和下载文件名的标题。
+func (m Meta) DisplayTitle() string {
+	if m.Title != "" {
+		return m.Title
+	}
+	if m.FileName != "" {
+		return strings.TrimSuffix(m.FileName, path.Ext(m.FileName))
+	}
+	return m.RequestId
+}
+
+// File 是渲染好的导出文件。
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Transcript 按 format 渲染转写内容。
+func Transcript(format string, meta Meta, result *lark.Result, opts Options) (*File, error) {
+	if result == nil || len(result.Utterances) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, "任务没有可导出的转写结果")
+	}
+	if opts.Mode == "" {
+		opts.Mode = ModeSource
+	}
+	if opts.Mode != ModeSource && len(result.Translations) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, "任务没有翻译结果，无法导出译文")
+	}
+	segments := Segments(result, opts.MergeSpeaker)
+
+	var (
+		data []byte
+		err  error
+	)
+	switch format {
+	case FormatSRT:
+		data = []byte(renderSRT(subtitleCues(segments, opts)))
+	case FormatVTT:
+		data = []byte(renderVTT(subtitleCues(segments, opts)))
+	case FormatTXT:
+		data = []byte(renderTXT(segments, opts))
+	case FormatMD:
+		data = []byte(renderMarkdown(meta, segments, opts))
+	case FormatDOCX:
+		data, err = renderDocx(meta, segments, opts)
+	case FormatJSON:
+		data, err = json.MarshalIndent(segments, "", "  ")
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "不支持的导出格式：%s", format)
+	}
+	if err != nil {
+		return nil, gerror.Wrap(err, "渲染导出文件失败")
+	}
+	suffix := opts.Mode
+	if suffix == ModeSource {
+		suffix = ""
+	}
+	return NewFile(meta, suffix, format, data), nil
+}
+
+// NewFile 按任务标题生成下载文件名。suffix 用于区分同一格式的不同导出模式，例如 "bilingual"。
+func NewFile(meta Meta, suffix, format string, data []byte) *File {
+	name := meta.DisplayTitle()
+	if suffix != "" {
+		name += "." + suffix
+	}
+	return &File{
+		Name:        fmt.Sprintf("%s.%s", name, format),
+		ContentType: contentTypes[format],
+		Data:        data,
+	}
+}
+
+func renderTXT(segments []Segment, opts Options) string {
+	var b strings.Builder
+	for _, s := range segments {
+		if opts.Timestamps {
+			fmt.Fprintf(&b, "[%s] ", Clock(s.StartTime))
+		}
+		if opts.SpeakerLabels && s.Speaker != "" {
+			fmt.Fprintf(&b, "%s：", s.Speaker)
+		}
+		b.WriteString(s.Text(opts.Mode))
+		b.WriteString("\n")
+		if opts.Mode == ModeBilingual {
+			b.WriteString(s.Translation)
+			b.WriteString("\n\n")
+		}
+	}
+	return b.String()
+}
+
+func renderMarkdown(meta Meta, segments []Segment, opts Options) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", meta.DisplayTitle())
+	if meta.CreatedAt != nil {
+		fmt.Fprintf(&b, "> %s\n\n", meta.CreatedAt.Format("Y-m-d H:i"))
+	}
+	if opts.Mode == ModeBilingual {
+		b.WriteString(markdownTable(bilingualRows(segments, opts)))
+		return b.String()
+	}
+	for _, s := range segments {
+		var head []string
+		if opts.SpeakerLabels && s.Speaker != "" {
+			head = append(head, "**"+MarkdownEscape(s.Speaker)+"**")
+		}
+		if opts.Timestamps {
+			head = append(head, "`"+Clock(s.StartTime)+"`")
+		}
+		if len(head) > 0 {
+			b.WriteString(strings.Join(head, " "))
+			b.WriteString("\n\n")
+		}
+		b.WriteString(MarkdownEscape(s.Text(opts.Mode)))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+func renderDocx(meta Meta, segments []Segment, opts Options) ([]byte, error) {
+	doc := NewDocx()
+	doc.Heading(1, meta.DisplayTitle())
+	if meta.CreatedAt != nil {
+		doc.Paragraph(meta.CreatedAt.Format("Y-m-d H:i"))
+	}
+	if opts.Mode == ModeBilingual {
+		doc.Table(bilingualRows(segments, opts))
+		return doc.Bytes()
+	}
+	for _, s := range segments {
+		var head []string
+		if opts.SpeakerLabels && s.Speaker != "" {
+			head = append(head, s.Speaker)
+		}
+		if opts.Timestamps {
+			head = append(head, Clock(s.StartTime))
+		}
+		if len(head) > 0 {
+			doc.Label(strings.Join(head, "  "))
+		}
+		doc.Paragraph(s.Text(opts.Mode))
+	}
+	return doc.Bytes()
+}
+
+// bilingualRows 生成双栏对照表，第一行为表头。时间和说话人列按选项输出。
+func bilingualRows(segments []Segment, opts Options) [][]string {
+	var header []string
+	if opts.Timestamps {
+		header = append(header, "时间")
+	}
+	if opts.SpeakerLabels {
+		header = append(header, "说话人")
+	}
+	rows := [][]string{append(header, "原文", "译文")}
+	for _, s := range segments {
+		var row []string
+		if opts.Timestamps {
+			row = append(row, Clock(s.StartTime))
+		}
+		if opts.SpeakerLabels {
+			row = append(row, s.Speaker)
+		}
+		rows = append(rows, append(row, s.Content, s.Translation))
+	}
+	return rows
+}
+
+// markdownTable 把第一行作为表头渲染 Markdown 表格。
+func markdownTable(rows [][]string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = MarkdownEscape(cell)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString(strings.Repeat("| --- ", len(row)) + "|\n")
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Clock 把毫秒格式化为 HH:MM:SS。
+func Clock(ms int64) string {
+	if ms < 0 {
+		ms = 0
+	}
+	s := ms / 1000
+	return fmt.Sprintf("%02d:%02d:%02d", s/3600, s/60%60, s%60)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "#", `\#`, "|", `\|`, "<", "&lt;", ">", "&gt;",
+)
+
+// MarkdownEscape 转义 Markdown 中有特殊含义的字符，并把换行折叠为空格。
+func MarkdownEscape(s string) string {
+	return markdownEscaper.Replace(strings.ReplaceAll(s, "\n", " "))
+}
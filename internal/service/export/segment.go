@@ -0,0 +1,118 @@
+package export
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"doubao-speech-service/internal/model/lark"
+)
+
+// Segment 是导出时的一段文本：一句转写，或合并后同一说话人连续的多句转写。
+type Segment struct {
+	SentenceIds []string `json:"sentenceIds"`
+	SpeakerId   string   `json:"speakerId"`
+	Speaker     string   `json:"speaker"`
+	Content     string   `json:"content"`
+	Translation string   `json:"translation,omitempty"`
+	StartTime   int64    `json:"startTime"`
+	EndTime     int64    `json:"endTime"`
+}
+
+// Segments 把转写句子转换为导出段落，并附上对齐后的译文。merge 为 true 时合并同一说话人连续的句子。
+func Segments(result *lark.Result, merge bool) []Segment {
+	translations := AlignTranslations(result)
+	segments := make([]Segment, 0, len(result.Utterances))
+	for i, u := range result.Utterances {
+		if merge && len(segments) > 0 {
+			last := &segments[len(segments)-1]
+			if last.SpeakerId == u.SpeakerId {
+				last.SentenceIds = append(last.SentenceIds, u.SentenceId)
+				last.Content = JoinText(last.Content, u.Content)
+				last.Translation = JoinText(last.Translation, translations[i])
+				if u.EndTime > last.EndTime {
+					last.EndTime = u.EndTime
+				}
+				continue
+			}
+		}
+		segments = append(segments, Segment{
+			SentenceIds: []string{u.SentenceId},
+			SpeakerId:   u.SpeakerId,
+			Speaker:     SpeakerName(result, u.SpeakerId),
+			Content:     u.Content,
+			Translation: translations[i],
+			StartTime:   u.StartTime,
+			EndTime:     u.EndTime,
+		})
+	}
+	return segments
+}
+
+// Text 按导出模式返回段落文本：target 返回译文，其余返回原文。
+func (s Segment) Text(mode string) string {
+	if mode == ModeTarget {
+		return s.Translation
+	}
+	return s.Content
+}
+
+// AlignTranslations 把译文对齐到转写句子，返回与 Utterances 等长的译文列表。
+// 优先按 sentence_id 对齐；没有 sentence_id 或对不上时，取时间区间重叠最多的译文。
+func AlignTranslations(result *lark.Result) []string {
+	aligned := make([]string, len(result.Utterances))
+	if len(result.Translations) == 0 {
+		return aligned
+	}
+	byId := make(map[string]string, len(result.Translations))
+	for _, t := range result.Translations {
+		if t.SentenceId != "" {
+			byId[t.SentenceId] = t.Translation
+		}
+	}
+	for i, u := range result.Utterances {
+		if text, ok := byId[u.SentenceId]; ok && u.SentenceId != "" {
+			aligned[i] = text
+			continue
+		}
+		var best int64
+		for _, t := range result.Translations {
+			if overlap := min(u.EndTime, t.EndTime) - max(u.StartTime, t.StartTime); overlap > best ||
+				(best == 0 && t.StartTime == u.StartTime) {
+				best = overlap
+				aligned[i] = t.Translation
+			}
+		}
+	}
+	return aligned
+}
+
+// SpeakerName 返回说话人的显示名称，结果中没有名称时使用 “说话人{id}”。未识别说话人时返回空字符串。
+func SpeakerName(result *lark.Result, id string) string {
+	if id == "" {
+		return ""
+	}
+	if s, ok := result.Speaker(id); ok && s.Name != "" {
+		return s.Name
+	}
+	return "说话人" + id
+}
+
+// JoinText 拼接两段文本：两侧都是西文字符时用空格分隔，中文直接拼接。
+func JoinText(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	last, _ := utf8.DecodeLastRuneInString(a)
+	first, _ := utf8.DecodeRuneInString(b)
+	if isWide(last) || isWide(first) {
+		return a + b
+	}
+	return a + " " + b
+}
+
+func isWide(r rune) bool {
+	return r > unicode.MaxLatin1 && !unicode.IsSpace(r)
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// cue 是一条字幕。
+type cue struct {
+	StartTime int64
+	EndTime   int64
+	Speaker   string // 为空时不输出说话人
+	Lines     []string
+}
+
+func subtitleCues(segments []Segment, opts Options) []cue {
+	cues := make([]cue, 0, len(segments))
+	for _, s := range segments {
+		c := cue{
+			StartTime: s.StartTime,
+			EndTime:   s.EndTime,
+			Lines:     Wrap(s.Text(opts.Mode), opts.MaxLineLength),
+		}
+		if opts.Mode == ModeBilingual && s.Translation != "" {
+			c.Lines = append(c.Lines, Wrap(s.Translation, opts.MaxLineLength)...)
+		}
+		if opts.SpeakerLabels {
+			c.Speaker = s.Speaker
+		}
+		cues = append(cues, c)
+	}
+	return cues
+}
+
+func renderSRT(cues []cue) string {
+	var b strings.Builder
+	for i, c := range cues {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n", i+1, subtitleTime(c.StartTime, ','), subtitleTime(c.EndTime, ','))
+		for j, line := range c.Lines {
+			if j == 0 && c.Speaker != "" {
+				line = c.Speaker + "：" + line
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func renderVTT(cues []cue) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, c := range cues {
+		fmt.Fprintf(&b, "%s --> %s\n", subtitleTime(c.StartTime, '.'), subtitleTime(c.EndTime, '.'))
+		for j, line := range c.Lines {
+			line = vttEscaper.Replace(line)
+			if j == 0 && c.Speaker != "" {
+				line = "<v " + vttEscaper.Replace(c.Speaker) + ">" + line
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// subtitleTime 把毫秒格式化为 HH:MM:SS,mmm（SRT）或 HH:MM:SS.mmm（WebVTT）。
+func subtitleTime(ms int64, sep byte) string {
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%s%c%03d", Clock(ms), sep, ms%1000)
+}
+
+// Wrap 把文本按每行最多 n 个字符折行，西文尽量在空格处断开。n <= 0 时不折行。
+func Wrap(s string, n int) []string {
+	s = strings.TrimSpace(s)
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return []string{s}
+	}
+	var lines []string
+	runes := []rune(s)
+	for len(runes) > n {
+		cut := n
+		for i := n; i > n/2; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimSpace(string(runes[:cut])))
+		runes = []rune(strings.TrimLeftFunc(string(runes[cut:]), unicode.IsSpace))
+	}
+	if len(runes) > 0 {
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
@@ -0,0 +1,46 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Clip 用 ffmpeg 截取 input 中从 start 开始、长度为 duration 的片段，转换为目标格式后写入 outputPath。
+// input 可以是本地路径，也可以是 ffmpeg 能读取的 URL（例如 TOS 的预签名地址），ffmpeg 只读取需要的部分。
+func (c *FFmpegConverter) Clip(ctx context.Context, input, outputPath string, start, duration time.Duration) error {
+	if duration <= 0 {
+		return gerror.New("clip duration must be positive")
+	}
+	// -ss 放在 -i 之前按关键帧快速定位，转码时仍然精确到采样
+	args := []string{
+		"-y",
+		"-ss", fmt.Sprintf("%.3f", start.Seconds()),
+		"-i", input,
+		"-t", fmt.Sprintf("%.3f", duration.Seconds()),
+		"-vn",
+	}
+	if c.opts.AudioBitrate != "" {
+		args = append(args, "-b:a", c.opts.AudioBitrate)
+	}
+	if len(c.opts.ExtraArgs) > 0 {
+		args = append(args, c.opts.ExtraArgs...)
+	}
+	args = append(args, "-f", c.opts.TargetFormat, outputPath)
+
+	cmd := exec.CommandContext(ctx, c.binPath, args...)
+	cmd.Stdout = io.Discard
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return gerror.Wrapf(err, "ffmpeg clip to %s failed: %s", c.opts.TargetFormat, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
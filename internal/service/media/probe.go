@@ -0,0 +1,137 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Info 是 ffprobe 探测到的媒体信息，保存在任务的 file_info.media_info 中。时长单位为毫秒，码率单位为 bit/s。
+type Info struct {
+	Duration   int64        `json:"duration"`
+	FormatName string       `json:"format_name"`
+	BitRate    int64        `json:"bit_rate"`
+	Streams    []StreamInfo `json:"streams"`
+}
+
+// StreamInfo 是一路音频、视频或字幕流的信息，音频和视频各自的字段在另一种流中为空。
+type StreamInfo struct {
+	Index         int    `json:"index"`
+	CodecType     string `json:"codec_type"` // audio、video、subtitle、data
+	CodecName     string `json:"codec_name"`
+	Duration      int64  `json:"duration,omitempty"`
+	BitRate       int64  `json:"bit_rate,omitempty"`
+	SampleRate    int    `json:"sample_rate,omitempty"`
+	Channels      int    `json:"channels,omitempty"`
+	ChannelLayout string `json:"channel_layout,omitempty"`
+	Width         int    `json:"width,omitempty"`
+	Height        int    `json:"height,omitempty"`
+}
+
+// HasAudio 返回是否包含音频流。
+func (i *Info) HasAudio() bool {
+	for _, s := range i.Streams {
+		if s.CodecType == "audio" {
+			return true
+		}
+	}
+	return false
+}
+
+// FFprobe 探测器
+type FFprobe struct {
+	binPath string
+}
+
+func NewProbe(binPath string) *FFprobe {
+	return &FFprobe{binPath: binPath}
+}
+
+// ffprobeOutput 是 ffprobe -print_format json 的输出。数字字段大多以字符串给出。
+type ffprobeOutput struct {
+	Format struct {
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+		BitRate    string `json:"bit_rate"`
+	} `json:"format"`
+	Streams []struct {
+		Index         int    `json:"index"`
+		CodecType     string `json:"codec_type"`
+		CodecName     string `json:"codec_name"`
+		Duration      string `json:"duration"`
+		BitRate       string `json:"bit_rate"`
+		SampleRate    string `json:"sample_rate"`
+		Channels      int    `json:"channels"`
+		ChannelLayout string `json:"channel_layout"`
+		Width         int    `json:"width"`
+		Height        int    `json:"height"`
+	} `json:"streams"`
+}
+
+// Probe 用 ffprobe 读取 input 的封装格式和各路流的信息。input 可以是本地路径，也可以是 ffprobe 能读取的 URL。
+func (p *FFprobe) Probe(ctx context.Context, input string) (*Info, error) {
+	cmd := exec.CommandContext(ctx, p.binPath,
+		"-v", "error",
+		"-print_format", "json",
+		"-show_format", "-show_streams",
+		input,
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, gerror.Wrapf(err, "ffprobe failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	var out ffprobeOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, gerror.Wrap(err, "ffprobe output is not valid JSON")
+	}
+
+	info := &Info{
+		Duration:   parseSeconds(out.Format.Duration),
+		FormatName: out.Format.FormatName,
+		BitRate:    parseInt(out.Format.BitRate),
+		Streams:    make([]StreamInfo, 0, len(out.Streams)),
+	}
+	for _, s := range out.Streams {
+		info.Streams = append(info.Streams, StreamInfo{
+			Index:         s.Index,
+			CodecType:     s.CodecType,
+			CodecName:     s.CodecName,
+			Duration:      parseSeconds(s.Duration),
+			BitRate:       parseInt(s.BitRate),
+			SampleRate:    int(parseInt(s.SampleRate)),
+			Channels:      s.Channels,
+			ChannelLayout: s.ChannelLayout,
+			Width:         s.Width,
+			Height:        s.Height,
+		})
+	}
+	// 部分封装格式（例如 webm 录音）没有总时长，取最长的流
+	if info.Duration == 0 {
+		for _, s := range info.Streams {
+			info.Duration = max(info.Duration, s.Duration)
+		}
+	}
+	return info, nil
+}
+
+// parseSeconds 把 ffprobe 输出的秒数（例如 "12.345000"）转换为毫秒，无法解析时为 0。
+func parseSeconds(s string) int64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0
+	}
+	return int64(math.Round(v * 1000))
+}
+
+func parseInt(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
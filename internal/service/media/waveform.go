@@ -0,0 +1,201 @@
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Waveform 是单声道音频的波形峰值，格式与 audiowaveform（https://github.com/bbc/audiowaveform）一致，
+// 可以直接交给 peaks.js 等播放器使用。
+type Waveform struct {
+	SampleRate      int
+	SamplesPerPixel int
+	Data            []int16 // 每个点依次为最小值、最大值
+}
+
+// Length 返回点数。
+func (w *Waveform) Length() int {
+	return len(w.Data) / 2
+}
+
+// Waveform 用 ffmpeg 把 input 解码为单声道 16 位 PCM，边解码边计算每 samplesPerPixel 个采样的最小值和最大值，不保存解码后的音频。
+// input 可以是本地路径，也可以是 ffmpeg 能读取的 URL。
+func (c *FFmpegConverter) Waveform(ctx context.Context, input string, sampleRate, samplesPerPixel int) (*Waveform, error) {
+	if samplesPerPixel <= 0 {
+		return nil, gerror.New("samples per pixel must be positive")
+	}
+	args := []string{
+		"-i", input,
+		"-vn", "-ac", "1", "-ar", strconv.Itoa(sampleRate),
+		"-f", "s16le", "-acodec", "pcm_s16le",
+		"pipe:1",
+	}
+	cmd := exec.CommandContext(ctx, c.binPath, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, gerror.Wrap(err, "ffmpeg stdout pipe failed")
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, gerror.Wrap(err, "ffmpeg start failed")
+	}
+
+	w := &Waveform{SampleRate: sampleRate, SamplesPerPixel: samplesPerPixel}
+	var (
+		reader   = bufio.NewReaderSize(stdout, 64*1024)
+		sample   [2]byte
+		count    int
+		min, max int16
+	)
+	for {
+		if _, err = io.ReadFull(reader, sample[:]); err != nil {
+			break
+		}
+		v := int16(binary.LittleEndian.Uint16(sample[:]))
+		if count == 0 || v < min {
+			min = v
+		}
+		if count == 0 || v > max {
+			max = v
+		}
+		if count++; count == samplesPerPixel {
+			w.Data = append(w.Data, min, max)
+			count = 0
+		}
+	}
+	if count > 0 {
+		w.Data = append(w.Data, min, max)
+	}
+	// 读到结尾之外的错误说明管道异常，让 ffmpeg 退出
+	if err != io.EOF && err != io.ErrUnexpectedEOF {
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	if err = cmd.Wait(); err != nil {
+		return nil, gerror.Wrapf(err, "ffmpeg decode failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return w, nil
+}
+
+// Resample 把每 factor 个相邻的点合并为一个点，得到更粗的波形。
+func (w *Waveform) Resample(factor int) *Waveform {
+	if factor <= 1 {
+		return w
+	}
+	out := &Waveform{
+		SampleRate:      w.SampleRate,
+		SamplesPerPixel: w.SamplesPerPixel * factor,
+		Data:            make([]int16, 0, (w.Length()+factor-1)/factor*2),
+	}
+	for i := 0; i < w.Length(); i += factor {
+		min, max := w.Data[i*2], w.Data[i*2+1]
+		for j := i + 1; j < i+factor && j < w.Length(); j++ {
+			min = minInt16(min, w.Data[j*2])
+			max = maxInt16(max, w.Data[j*2+1])
+		}
+		out.Data = append(out.Data, min, max)
+	}
+	return out
+}
+
+// JSON 返回 audiowaveform 的 JSON 格式。bits 为 8 时数据缩放到 -128 ~ 127。
+func (w *Waveform) JSON(bits int) ([]byte, error) {
+	data := make([]int, len(w.Data))
+	for i, v := range w.Data {
+		data[i] = scale(v, bits)
+	}
+	return json.Marshal(struct {
+		Version         int   `json:"version"`
+		Channels        int   `json:"channels"`
+		SampleRate      int   `json:"sample_rate"`
+		SamplesPerPixel int   `json:"samples_per_pixel"`
+		Bits            int   `json:"bits"`
+		Length          int   `json:"length"`
+		Data            []int `json:"data"`
+	}{2, 1, w.SampleRate, w.SamplesPerPixel, bits, w.Length(), data})
+}
+
+// Binary 返回 audiowaveform 的二进制格式（.dat，版本 2），所有字段均为小端序。
+func (w *Waveform) Binary(bits int) []byte {
+	var (
+		buf   bytes.Buffer
+		flags uint32
+	)
+	if bits == 8 {
+		flags = 1
+	}
+	_ = binary.Write(&buf, binary.LittleEndian, struct {
+		Version         int32
+		Flags           uint32
+		SampleRate      int32
+		SamplesPerPixel int32
+		Length          uint32
+		Channels        int32
+	}{2, flags, int32(w.SampleRate), int32(w.SamplesPerPixel), uint32(w.Length()), 1})
+	for _, v := range w.Data {
+		if bits == 8 {
+			buf.WriteByte(byte(int8(scale(v, 8))))
+		} else {
+			_ = binary.Write(&buf, binary.LittleEndian, v)
+		}
+	}
+	return buf.Bytes()
+}
+
+// ParseWaveform 解析 Binary 输出的单声道 16 位二进制格式。
+func ParseWaveform(data []byte) (*Waveform, error) {
+	var header struct {
+		Version         int32
+		Flags           uint32
+		SampleRate      int32
+		SamplesPerPixel int32
+		Length          uint32
+		Channels        int32
+	}
+	r := bytes.NewReader(data)
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, gerror.Wrap(err, "波形数据格式错误")
+	}
+	if header.Version != 2 || header.Flags != 0 || header.Channels != 1 {
+		return nil, gerror.Newf("不支持的波形数据：version=%d flags=%d channels=%d", header.Version, header.Flags, header.Channels)
+	}
+	w := &Waveform{
+		SampleRate:      int(header.SampleRate),
+		SamplesPerPixel: int(header.SamplesPerPixel),
+		Data:            make([]int16, header.Length*2),
+	}
+	if err := binary.Read(r, binary.LittleEndian, w.Data); err != nil {
+		return nil, gerror.Wrap(err, "波形数据不完整")
+	}
+	return w, nil
+}
+
+func scale(v int16, bits int) int {
+	if bits == 8 {
+		return int(v) >> 8
+	}
+	return int(v)
+}
+
+func minInt16(a, b int16) int16 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt16(a, b int16) int16 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"doubao-speech-service/internal/service/media"
+	"doubao-speech-service/internal/service/volcengine"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
@@ -51,6 +52,7 @@ type RecordingResult struct {
 	Size      int64
 	StartedAt time.Time
 	EndedAt   time.Time
+	MediaInfo *media.Info // ffprobe 探测到的媒体信息，未配置 ffprobe 或探测失败时为 nil
 }
 
 func NewRecorder(ctx context.Context) (*Recorder, error) {
@@ -160,6 +162,8 @@ func (r *Recorder) Finalize() (*RecordingResult, error) {
 		Size:      info.Size(),
 		StartedAt: r.startTime,
 		EndedAt:   time.Now(),
+		// 探测一次，完成消息中的时长和上传时的检查都使用这个结果
+		MediaInfo: volcengine.ProbeMedia(r.ctx, r.filePath),
 	}
 	g.Log().Infof(r.ctx, "Finalize 完成，最终文件大小: %d bytes", result.Size)
 	return result, nil
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/transcription"
 	"doubao-speech-service/internal/service/volcengine"
 )
 
@@ -26,9 +30,17 @@ func uploadWorker(ctx context.Context) {
 		case item := <-uploadQueue:
 			if err := uploadOne(ctx, item); err != nil {
 				g.Log().Warningf(ctx, "record upload failed, connect_id=%s: %v", item.ConnectID, err)
+				// 文件本身不可用（例如没有音频）时重试也没有意义，任务标记为失败并删除本地文件
+				if gerror.Code(err) == gcode.CodeInvalidParameter {
+					rejectUpload(ctx, item, err)
+				}
 				continue
 			}
 			g.Log().Infof(ctx, "record upload completed, connect_id=%s, size=%d bytes", item.ConnectID, item.Size)
+			// 趁本地文件还在生成波形，失败时在第一次请求波形时从 TOS 重新生成
+			if _, err := transcription.GenerateWaveform(ctx, item.ConnectID, item.FilePath); err != nil {
+				g.Log().Warningf(ctx, "record waveform failed, connect_id=%s: %v", item.ConnectID, err)
+			}
 			_ = os.Remove(item.FilePath)
 			_ = os.Remove(item.Dir)
 		}
@@ -52,10 +64,23 @@ func uploadOne(ctx context.Context, item RecordingResult) error {
 	if err != nil {
 		return err
 	}
-	res := volcengine.ProcessFileUpload(ctx, uploadFile, item.Owner, item.ConnectID)
+	res := volcengine.ProcessFileUpload(ctx, uploadFile, item.Owner, item.MediaInfo, item.ConnectID)
 	return res.Error
 }
 
+// rejectUpload 把不可用文件的任务标记为失败，并删除本地文件。
+func rejectUpload(ctx context.Context, item RecordingResult, reason error) {
+	if _, err := dao.Transcription.Ctx(ctx).
+		Data(g.Map{"status": "failed"}).
+		Where("request_id = ?", item.ConnectID).
+		Update(); err != nil {
+		g.Log().Errorf(ctx, "record mark failed error, connect_id=%s: %v", item.ConnectID, err)
+	}
+	g.Log().Infof(ctx, "record rejected, connect_id=%s: %v", item.ConnectID, reason)
+	_ = os.Remove(item.FilePath)
+	_ = os.Remove(item.Dir)
+}
+
 // EnqueueUpload 将录音结果加入上传队列。
 func EnqueueUpload(ctx context.Context, result *RecordingResult) {
 	if result == nil || uploadQueue == nil {
@@ -178,12 +178,17 @@ func ProxyWebSocket(
 }
 
 func sendTaskCompleteMessage(ctx context.Context, conn *websocket.Conn, result *RecordingResult) error {
+	// 优先使用录音文件的实际时长，探测不到时退回到录音的起止时间
+	duration := result.EndedAt.Sub(result.StartedAt).Seconds()
+	if info := result.MediaInfo; info != nil && info.Duration > 0 {
+		duration = float64(info.Duration) / 1000
+	}
 	taskInfo := g.Map{
 		"taskId":    result.ConnectID,
 		"connectId": result.ConnectID,
 		"filePath":  result.FilePath,
 		"fileSize":  result.Size,
-		"duration":  result.EndedAt.Sub(result.StartedAt).Seconds(),
+		"duration":  duration,
 		"startedAt": result.StartedAt.Format(time.RFC3339),
 		"endedAt":   result.EndedAt.Format(time.RFC3339),
 	}
@@ -0,0 +1,278 @@
+package minutes
+
+import (
+	"html"
+	"strings"
+
+	"doubao-speech-service/internal/service/export"
+)
+
+// 纪要模板只需要 Markdown 的一个小子集：标题、段落、列表、引用、表格和加粗。
+// 这里把渲染后的 Markdown 解析为块，再分别输出 HTML 和 DOCX。
+
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockBullet
+	blockOrdered
+	blockQuote
+	blockTable
+	blockRule
+)
+
+type block struct {
+	kind  blockKind
+	level int        // 标题级别
+	text  string     // 段落、标题、列表项、引用的原始 Markdown 文本
+	rows  [][]string // 表格，第一行为表头
+}
+
+func parseBlocks(markdown string) []block {
+	var (
+		blocks []block
+		para   []string
+	)
+	flush := func() {
+		if len(para) > 0 {
+			text := para[0]
+			for _, line := range para[1:] {
+				text = export.JoinText(text, line)
+			}
+			blocks = append(blocks, block{kind: blockParagraph, text: text})
+			para = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			if level > 6 || !strings.HasPrefix(line[level:], " ") {
+				para = append(para, line)
+				continue
+			}
+			flush()
+			blocks = append(blocks, block{kind: blockHeading, level: level, text: strings.TrimSpace(line[level:])})
+		case line == "---" || line == "***":
+			flush()
+			blocks = append(blocks, block{kind: blockRule})
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+			flush()
+			blocks = append(blocks, block{kind: blockBullet, text: strings.TrimSpace(line[2:])})
+		case orderedItem(line) != "":
+			flush()
+			blocks = append(blocks, block{kind: blockOrdered, text: orderedItem(line)})
+		case strings.HasPrefix(line, ">"):
+			flush()
+			blocks = append(blocks, block{kind: blockQuote, text: strings.TrimSpace(line[1:])})
+		case strings.HasPrefix(line, "|"):
+			flush()
+			t := block{kind: blockTable}
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				row := strings.TrimSpace(lines[i])
+				if isTableSeparator(row) {
+					continue
+				}
+				t.rows = append(t.rows, splitRow(row))
+			}
+			i--
+			blocks = append(blocks, t)
+		default:
+			para = append(para, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// orderedItem 识别 “1. xxx” 形式的有序列表项，返回列表项文本。
+func orderedItem(line string) string {
+	digits := len(line) - len(strings.TrimLeft(line, "0123456789"))
+	if digits == 0 || !strings.HasPrefix(line[digits:], ". ") {
+		return ""
+	}
+	return strings.TrimSpace(line[digits+2:])
+}
+
+func isTableSeparator(row string) bool {
+	return strings.Trim(row, "|-: ") == "" && strings.Contains(row, "-")
+}
+
+// splitRow 按未转义的 | 拆分表格行。
+func splitRow(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	var (
+		cells []string
+		cell  strings.Builder
+	)
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row):
+			cell.WriteByte(row[i])
+			cell.WriteByte(row[i+1])
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseInline 解析行内格式：反斜杠转义、**加粗**、`代码`（只去掉反引号）以及 &lt; &gt; &amp; 实体。
+func parseInline(text string) []export.Run {
+	var (
+		runs []export.Run
+		cur  strings.Builder
+		bold bool
+	)
+	emit := func() {
+		if cur.Len() > 0 {
+			runs = append(runs, export.Run{Text: cur.String(), Bold: bold})
+			cur.Reset()
+		}
+	}
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && i+1 < len(text):
+			i++
+			cur.WriteByte(text[i])
+		case strings.HasPrefix(text[i:], "**"):
+			emit()
+			bold = !bold
+			i++
+		case text[i] == '`':
+		case text[i] == '&':
+			entity := text[i:min(len(text), i+5)]
+			switch {
+			case strings.HasPrefix(entity, "&lt;"):
+				cur.WriteByte('<')
+				i += 3
+			case strings.HasPrefix(entity, "&gt;"):
+				cur.WriteByte('>')
+				i += 3
+			case strings.HasPrefix(entity, "&amp;"):
+				cur.WriteByte('&')
+				i += 4
+			default:
+				cur.WriteByte('&')
+			}
+		default:
+			cur.WriteByte(text[i])
+		}
+	}
+	emit()
+	return runs
+}
+
+func plainText(text string) string {
+	var b strings.Builder
+	for _, r := range parseInline(text) {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+func inlineHTML(text string) string {
+	var b strings.Builder
+	for _, r := range parseInline(text) {
+		if r.Bold {
+			b.WriteString("<strong>" + html.EscapeString(r.Text) + "</strong>")
+		} else {
+			b.WriteString(html.EscapeString(r.Text))
+		}
+	}
+	return b.String()
+}
+
+// ToHTML 把纪要 Markdown 转换为独立的 HTML 页面。
+func ToHTML(title, markdown string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"zh-CN\">\n<head>\n<meta charset=\"utf-8\">\n<title>")
+	b.WriteString(html.EscapeString(title))
+	b.WriteString("</title>\n<style>" + htmlStyle + "</style>\n</head>\n<body>\n")
+
+	blocks := parseBlocks(markdown)
+	for i, blk := range blocks {
+		switch blk.kind {
+		case blockHeading:
+			level := string(rune('0' + min(blk.level, 6)))
+			b.WriteString("<h" + level + ">" + inlineHTML(blk.text) + "</h" + level + ">\n")
+		case blockBullet, blockOrdered:
+			tag := "ul"
+			if blk.kind == blockOrdered {
+				tag = "ol"
+			}
+			if i == 0 || blocks[i-1].kind != blk.kind {
+				b.WriteString("<" + tag + ">\n")
+			}
+			b.WriteString("<li>" + inlineHTML(blk.text) + "</li>\n")
+			if i == len(blocks)-1 || blocks[i+1].kind != blk.kind {
+				b.WriteString("</" + tag + ">\n")
+			}
+		case blockQuote:
+			b.WriteString("<blockquote>" + inlineHTML(blk.text) + "</blockquote>\n")
+		case blockRule:
+			b.WriteString("<hr>\n")
+		case blockTable:
+			b.WriteString("<table>\n")
+			for r, row := range blk.rows {
+				cellTag := "td"
+				if r == 0 {
+					cellTag = "th"
+				}
+				b.WriteString("<tr>")
+				for _, cell := range row {
+					b.WriteString("<" + cellTag + ">" + inlineHTML(cell) + "</" + cellTag + ">")
+				}
+				b.WriteString("</tr>\n")
+			}
+			b.WriteString("</table>\n")
+		default:
+			b.WriteString("<p>" + inlineHTML(blk.text) + "</p>\n")
+		}
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+const htmlStyle = `body{font-family:-apple-system,"Segoe UI","Microsoft YaHei",sans-serif;max-width:860px;margin:2em auto;padding:0 1em;line-height:1.7;color:#222}` +
+	`h1{color:#5a2d82;border-bottom:2px solid #5a2d82;padding-bottom:.3em}h2{color:#5a2d82;margin-top:1.6em}` +
+	`table{border-collapse:collapse;width:100%}th,td{border:1px solid #ccc;padding:.4em .6em;text-align:left}th{background:#f3eef8}` +
+	`blockquote{color:#666;border-left:4px solid #ddd;margin:1em 0;padding-left:1em}`
+
+// ToDocx 把纪要 Markdown 转换为 Word 文档。
+func ToDocx(markdown string) ([]byte, error) {
+	doc := export.NewDocx()
+	for _, blk := range parseBlocks(markdown) {
+		switch blk.kind {
+		case blockHeading:
+			doc.Heading(blk.level, plainText(blk.text))
+		case blockBullet, blockOrdered:
+			doc.Bullet(parseInline(blk.text)...)
+		case blockQuote:
+			doc.RichParagraph(parseInline(blk.text)...)
+		case blockRule:
+		case blockTable:
+			rows := make([][]string, len(blk.rows))
+			for r, row := range blk.rows {
+				rows[r] = make([]string, len(row))
+				for c, cell := range row {
+					rows[r][c] = plainText(cell)
+				}
+			}
+			doc.Table(rows)
+		default:
+			doc.RichParagraph(parseInline(blk.text)...)
+		}
+	}
+	return doc.Bytes()
+}
@@ -0,0 +1,154 @@
+// Package minutes 把全文总结、章节、待办、问答、发言统计和任务元数据组合成一份会议纪要。
+//
+// 纪要模板是 Markdown 格式的 Go text/template，可以按团队空间保存自定义模板。
+// 渲染出的 Markdown 再转换为 HTML 或 DOCX。
+package minutes
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/export"
+)
+
+// 支持的纪要格式
+const (
+	FormatMD   = export.FormatMD
+	FormatHTML = export.FormatHTML
+	FormatDOCX = export.FormatDOCX
+)
+
+// Data 是传给纪要模板的数据。时间单位均为毫秒。
+type Data struct {
+	Title           string
+	RequestId       string
+	FileName        string
+	Date            string // 会议（任务创建）时间，格式 2006-01-02 15:04
+	Duration        int64
+	Summary         *lark.Summary
+	Chapters        []lark.Chapter
+	Todos           []lark.Todo
+	QuestionAnswers []lark.QuestionAnswer
+	Speakers        []SpeakerStat // 按发言时长倒序
+	GeneratedAt     string
+}
+
+// SpeakerStat 是单个说话人的发言统计。
+type SpeakerStat struct {
+	Id         string
+	Name       string
+	Utterances int     // 发言句数
+	Duration   int64   // 发言总时长
+	Share      float64 // 发言时长占比（0~100）
+}
+
+// NewData 从任务元数据和类型化结果组装模板数据。
+func NewData(meta export.Meta, duration int64, result *lark.Result) *Data {
+	data := &Data{
+		Title:       meta.DisplayTitle(),
+		RequestId:   meta.RequestId,
+		FileName:    meta.FileName,
+		Duration:    duration,
+		GeneratedAt: gtime.Now().Format("Y-m-d H:i"),
+	}
+	if meta.CreatedAt != nil {
+		data.Date = meta.CreatedAt.Format("Y-m-d H:i")
+	}
+	if result == nil {
+		return data
+	}
+	if data.Duration == 0 {
+		data.Duration = result.Duration()
+	}
+	data.Summary = result.Summary
+	data.Chapters = result.Chapters
+	data.Todos = result.Todos
+	data.QuestionAnswers = result.QuestionAnswers
+	data.Speakers = speakerStats(result)
+	return data
+}
+
+func speakerStats(result *lark.Result) []SpeakerStat {
+	var (
+		stats = map[string]*SpeakerStat{}
+		order []string
+		total int64
+	)
+	for _, u := range result.Utterances {
+		if u.SpeakerId == "" {
+			continue
+		}
+		stat, ok := stats[u.SpeakerId]
+		if !ok {
+			stat = &SpeakerStat{Id: u.SpeakerId, Name: export.SpeakerName(result, u.SpeakerId)}
+			stats[u.SpeakerId] = stat
+			order = append(order, u.SpeakerId)
+		}
+		stat.Utterances++
+		stat.Duration += u.EndTime - u.StartTime
+		total += u.EndTime - u.StartTime
+	}
+	out := make([]SpeakerStat, 0, len(order))
+	for _, id := range order {
+		stat := stats[id]
+		if total > 0 {
+			stat.Share = float64(stat.Duration) * 100 / float64(total)
+		}
+		out = append(out, *stat)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Duration > out[j].Duration
+	})
+	return out
+}
+
+// Render 用模板 content 渲染纪要，content 为空时使用默认模板。
+func Render(format string, meta export.Meta, data *Data, content string) (*export.File, error) {
+	tpl, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	markdown, err := Execute(tpl, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	switch format {
+	case FormatMD:
+		out = []byte(markdown)
+	case FormatHTML:
+		out = []byte(ToHTML(data.Title, markdown))
+	case FormatDOCX:
+		if out, err = ToDocx(markdown); err != nil {
+			return nil, gerror.Wrap(err, "生成 DOCX 失败")
+		}
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "不支持的纪要格式：%s", format)
+	}
+	return export.NewFile(meta, "minutes", format, out), nil
+}
+
+// LoadTemplate 读取团队空间中名为 name 的纪要模板内容。
+func LoadTemplate(ctx context.Context, workspaceId, name string) (string, error) {
+	var tpl *entity.MinutesTemplate
+	cols := dao.MinutesTemplate.Columns()
+	if err := dao.MinutesTemplate.Ctx(ctx).
+		Where(cols.WorkspaceId+" = ?", workspaceId).
+		Where(cols.Name+" = ?", name).
+		Limit(1).
+		Scan(&tpl); err != nil {
+		return "", gerror.WrapCode(gcode.CodeDbOperationError, err, "查询纪要模板失败")
+	}
+	if tpl == nil {
+		return "", gerror.NewCodef(gcode.CodeNotFound, "纪要模板不存在：%s", name)
+	}
+	return tpl.Content, nil
+}
@@ -0,0 +1,91 @@
+package minutes
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/export"
+)
+
+// DefaultTemplate 是 CUHK-SZ 默认纪要模板。
+//
+//go:embed templates/default.md
+var DefaultTemplate string
+
+// MaxTemplateSize 是自定义模板的最大字节数。
+const MaxTemplateSize = 64 * 1024
+
+var funcs = template.FuncMap{
+	"md":       export.MarkdownEscape,
+	"clock":    export.Clock,
+	"duration": humanDuration,
+	"percent":  func(v float64) string { return fmt.Sprintf("%.1f%%", v) },
+	"add":      func(a, b int) int { return a + b },
+}
+
+// Parse 解析 Markdown 纪要模板，content 为空时使用默认模板。
+func Parse(content string) (*template.Template, error) {
+	if strings.TrimSpace(content) == "" {
+		content = DefaultTemplate
+	}
+	if len(content) > MaxTemplateSize {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "纪要模板不能超过 %d 字节", MaxTemplateSize)
+	}
+	tpl, err := template.New("minutes").Funcs(funcs).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "纪要模板语法错误")
+	}
+	return tpl, nil
+}
+
+// Execute 渲染模板。
+func Execute(tpl *template.Template, data *Data) (string, error) {
+	var b strings.Builder
+	if err := tpl.Execute(&b, data); err != nil {
+		return "", gerror.WrapCode(gcode.CodeInvalidParameter, err, "渲染纪要模板失败")
+	}
+	return b.String(), nil
+}
+
+// Validate 解析模板并用示例数据试渲染一次，保存自定义模板前调用。
+func Validate(content string) error {
+	tpl, err := Parse(content)
+	if err != nil {
+		return err
+	}
+	_, err = Execute(tpl, sampleData)
+	return err
+}
+
+var sampleData = &Data{
+	Title:    "示例会议",
+	Date:     "2025-01-01 09:00",
+	Duration: 3600000,
+	Summary:  &lark.Summary{Title: "示例标题", Paragraph: "示例总结"},
+	Chapters: []lark.Chapter{{Title: "示例章节", Summary: "示例章节摘要", StartTime: 0, EndTime: 60000}},
+	Todos:    []lark.Todo{{Content: "示例待办", Executor: "说话人1"}},
+	QuestionAnswers: []lark.QuestionAnswer{
+		{Question: "示例问题", Answer: "示例回答"},
+	},
+	Speakers:    []SpeakerStat{{Id: "1", Name: "说话人1", Utterances: 1, Duration: 60000, Share: 100}},
+	GeneratedAt: "2025-01-01 10:00",
+}
+
+// humanDuration 把毫秒格式化为 “1 小时 5 分钟” 这样的时长。
+func humanDuration(ms int64) string {
+	s := ms / 1000
+	switch {
+	case s >= 3600:
+		return fmt.Sprintf("%d 小时 %d 分钟", s/3600, s/60%60)
+	case s >= 60:
+		return fmt.Sprintf("%d 分钟 %d 秒", s/60, s%60)
+	default:
+		return fmt.Sprintf("%d 秒", s)
+	}
+}
@@ -0,0 +1,255 @@
+package pagination
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"doubao-speech-service/internal/model"
+)
+
+// total 参数的取值
+const (
+	TotalNone     = "none"     // 不返回总数
+	TotalExact    = "exact"    // 精确总数
+	TotalEstimate = "estimate" // 最多统计 EstimateLimit 条，超过时返回 EstimateLimit 并标记为估算值
+)
+
+// EstimateLimit 估算总数时最多统计的记录数。
+const EstimateLimit = 1000
+
+// 查询结果中排序键的别名
+const sortKeyField = "pagination_sort_key"
+
+// Keyset 键集分页的排序方式：先按 Expr 排序，再按唯一列 Id 排序，保证分页稳定。
+type Keyset struct {
+	Expr string // 排序键 SQL 表达式，不能为 NULL
+	Id   string // 唯一的第二排序键列，一般是主键
+	Desc bool
+}
+
+// Params 分页参数。
+type Params struct {
+	Cursor string // 上一次返回的 next_cursor / prev_cursor，为空表示第一页
+	Limit  int
+	Total  string // none / exact / estimate
+}
+
+// Page 分页结果。
+type Page struct {
+	HasMore        bool   // 是否还有下一页
+	NextCursor     string // 下一页游标，没有下一页时为空
+	PrevCursor     string // 上一页游标，当前是第一页时为空
+	Total          int    // 总数，Params.Total 为 none 时为 0
+	TotalEstimated bool   // Total 是否为估算值（实际总数不少于 Total）
+}
+
+// Info 转换为接口返回的分页信息。
+func (p *Page) Info() model.PageInfo {
+	return model.PageInfo{
+		HasMore:        p.HasMore,
+		NextCursor:     p.NextCursor,
+		PrevCursor:     p.PrevCursor,
+		Total:          p.Total,
+		TotalEstimated: p.TotalEstimated,
+	}
+}
+
+// cursor 游标内容。排序键统一使用 PostgreSQL 的文本表示，避免时间等类型在序列化时丢失精度。
+type cursor struct {
+	Sort     string `json:"s"` // 排序方式签名，防止游标被用于其他排序
+	Key      string `json:"k"`
+	Id       int64  `json:"i"`
+	Backward bool   `json:"b"` // true 表示向前翻页（上一页）
+}
+
+// minSecretLength 是 pagination.secret 的最短长度（字节）
+const minSecretLength = 16
+
+var (
+	secretOnce sync.Once
+	secret     []byte
+)
+
+// Init 检查游标签名密钥 pagination.secret，服务启动时调用。
+// 密钥必须在所有副本之间一致且重启后不变，否则已发出的游标会失效，因此不允许缺省。
+func Init(ctx context.Context) error {
+	if len(signingKey(ctx)) < minSecretLength {
+		return gerror.NewCodef(gcode.CodeMissingConfiguration, "pagination.secret 未配置或短于 %d 字节", minSecretLength)
+	}
+	return nil
+}
+
+// signingKey 返回游标签名密钥，读取配置 pagination.secret。
+func signingKey(ctx context.Context) []byte {
+	secretOnce.Do(func() {
+		secret = []byte(g.Cfg().MustGet(ctx, "pagination.secret").String())
+	})
+	return secret
+}
+
+func (k Keyset) signature() string {
+	return fmt.Sprintf("%s|%s|%t", k.Expr, k.Id, k.Desc)
+}
+
+func sign(ctx context.Context, payload string) string {
+	mac := hmac.New(sha256.New, signingKey(ctx))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+func encodeCursor(ctx context.Context, c cursor) string {
+	data, _ := json.Marshal(c)
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + sign(ctx, payload)
+}
+
+func decodeCursor(ctx context.Context, token string, k Keyset) (*cursor, error) {
+	invalid := gerror.NewCode(gcode.CodeInvalidParameter, "cursor 无效或已过期")
+	payload, mac, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(mac), []byte(sign(ctx, payload))) {
+		return nil, invalid
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, invalid
+	}
+	var c cursor
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, invalid
+	}
+	if c.Sort != k.signature() {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "cursor 与当前排序方式不一致")
+	}
+	return &c, nil
+}
+
+// Order 给查询加上排序。
+func (k Keyset) Order(m *gdb.Model) *gdb.Model {
+	direction := "ASC"
+	if k.Desc {
+		direction = "DESC"
+	}
+	return m.Order(gdb.Raw(k.Expr+" "+direction), gdb.Raw(k.Id+" "+direction))
+}
+
+// After 返回排在锚点记录（排序键 key，主键 id）之后的记录的查询条件。
+func (k Keyset) After(key any, id int64) (string, []any) {
+	op := ">"
+	if k.Desc {
+		op = "<"
+	}
+	return fmt.Sprintf("((%s %s ?) OR (%s = ? AND %s %s ?))", k.Expr, op, k.Expr, k.Id, op),
+		[]any{key, key, id}
+}
+
+// SortKeyField 返回查询排序键文本表示的字段表达式，可用于查询锚点记录。
+func (k Keyset) SortKeyField() string {
+	return fmt.Sprintf("(%s)::text AS %s", k.Expr, sortKeyField)
+}
+
+// Cursor 根据锚点记录生成游标，用于兼容旧的基于记录 ID 的分页参数。
+// record 需包含 SortKeyField 和 Id 列。
+func (k Keyset) Cursor(ctx context.Context, record gdb.Record, idField string) string {
+	return encodeCursor(ctx, cursor{
+		Sort: k.signature(),
+		Key:  record[sortKeyField].String(),
+		Id:   record[idField].Int64(),
+	})
+}
+
+// Query 按键集分页查询。m 需包含所有筛选条件，但不要指定查询字段，查询字段通过 fields 传入，默认为 "*"；
+// idField 是 Keyset.Id 在查询结果中的字段名。返回的记录始终按 Keyset 的顺序排列。
+func Query(ctx context.Context, m *gdb.Model, k Keyset, idField string, p Params, fields ...any) (gdb.Result, *Page, error) {
+	page := &Page{}
+	var c *cursor
+	if p.Cursor != "" {
+		var err error
+		if c, err = decodeCursor(ctx, p.Cursor, k); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	switch p.Total {
+	case TotalExact:
+		total, err := m.Count()
+		if err != nil {
+			return nil, nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "统计总记录数失败")
+		}
+		page.Total = total
+	case TotalEstimate:
+		total, err := g.DB().Model("? AS t", m.Fields("1").Limit(EstimateLimit+1)).Ctx(ctx).Count()
+		if err != nil {
+			return nil, nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "统计总记录数失败")
+		}
+		if total > EstimateLimit {
+			page.Total, page.TotalEstimated = EstimateLimit, true
+		} else {
+			page.Total = total
+		}
+	}
+
+	// 向前翻页时反转排序方向取数据，再把结果倒过来
+	order := k
+	backward := c != nil && c.Backward
+	if backward {
+		order.Desc = !order.Desc
+	}
+	if len(fields) == 0 {
+		fields = []any{"*"}
+	}
+	query := m.Fields(fields...).Fields(k.SortKeyField())
+	if c != nil {
+		after, args := order.After(c.Key, c.Id)
+		query = query.Where(after, args...)
+	}
+	records, err := order.Order(query).Limit(p.Limit + 1).All()
+	if err != nil {
+		return nil, nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询数据库失败")
+	}
+	more := len(records) > p.Limit
+	if more {
+		records = records[:p.Limit]
+	}
+	if backward {
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+	}
+
+	// 向后翻页：more 表示还有下一页，有游标说明存在上一页；向前翻页反之
+	hasNext, hasPrev := more, c != nil
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if len(records) > 0 {
+		first, last := records[0], records[len(records)-1]
+		if hasNext {
+			page.NextCursor = encodeCursor(ctx, cursor{
+				Sort: k.signature(),
+				Key:  last[sortKeyField].String(),
+				Id:   last[idField].Int64(),
+			})
+		}
+		if hasPrev {
+			page.PrevCursor = encodeCursor(ctx, cursor{
+				Sort:     k.signature(),
+				Key:      first[sortKeyField].String(),
+				Id:       first[idField].Int64(),
+				Backward: true,
+			})
+		}
+	}
+	page.HasMore = page.NextCursor != ""
+	return records, page, nil
+}
@@ -0,0 +1,63 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"golang.org/x/crypto/bcrypt"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+)
+
+// NewLinkToken 生成公开分享链接使用的随机 token（URL 安全）。
+func NewLinkToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", gerror.Wrap(err, "生成分享链接 token 失败")
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashPassword 对分享链接的访问密码做 bcrypt 哈希。空密码返回空字符串，表示无需密码。
+func HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", gerror.Wrap(err, "分享链接密码哈希失败")
+	}
+	return string(hash), nil
+}
+
+// ResolveLink 校验公开分享链接：存在、未撤销、未过期且密码正确，返回链接记录。
+func ResolveLink(ctx context.Context, token, password string) (*entity.TranscriptionShareLink, error) {
+	var link *entity.TranscriptionShareLink
+	cols := dao.TranscriptionShareLink.Columns()
+	if err := dao.TranscriptionShareLink.Ctx(ctx).
+		Where(cols.Token+" = ?", token).
+		Limit(1).
+		Scan(&link); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询分享链接失败")
+	}
+	if link == nil || link.RevokedAt != nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "分享链接不存在或已被撤销")
+	}
+	if link.ExpiresAt != nil && link.ExpiresAt.Before(gtime.Now()) {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "分享链接已过期")
+	}
+	if link.PasswordHash != "" {
+		if password == "" {
+			return nil, gerror.NewCode(gcode.CodeNotAuthorized, "该分享链接需要访问密码")
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)); err != nil {
+			return nil, gerror.NewCode(gcode.CodeNotAuthorized, "访问密码错误")
+		}
+	}
+	return link, nil
+}
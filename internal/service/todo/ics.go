@@ -0,0 +1,97 @@
+package todo
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/service/export"
+)
+
+// Item 是导出到日历的一条待办。
+type Item struct {
+	TodoId    string
+	Content   string
+	Executor  string
+	Assignee  string
+	DueDate   *gtime.Time
+	StartTime int64
+	HasTime   bool // 是否关联到了转写句子
+	TaskTitle string
+}
+
+// ICS 把设置了截止日期的待办导出为 iCalendar 文件，每条待办是截止日期当天的全天事件。
+// 使用 VEVENT 而不是 VTODO，因为 Outlook 等客户端导入时会忽略 VTODO。
+func ICS(items []Item, now time.Time) []byte {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(fold(s))
+		b.WriteString("\r\n")
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//CUHK-SZ ITSO//Doubao Speech Service//ZH")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:会议待办")
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, item := range items {
+		if item.DueDate == nil {
+			continue
+		}
+		due := item.DueDate.Time
+		var desc []string
+		if item.TaskTitle != "" {
+			desc = append(desc, "会议："+item.TaskTitle)
+		}
+		if item.HasTime {
+			desc = append(desc, "会议时间点："+export.Clock(item.StartTime))
+		}
+		if item.Assignee != "" {
+			desc = append(desc, "负责人："+item.Assignee)
+		} else if item.Executor != "" {
+			desc = append(desc, "执行人："+item.Executor)
+		}
+
+		line("BEGIN:VEVENT")
+		line("UID:" + item.TodoId + "@doubao-speech-service")
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + due.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + due.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeText(item.Content))
+		if len(desc) > 0 {
+			line("DESCRIPTION:" + escapeText(strings.Join(desc, "\n")))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// escapeText 按 RFC 5545 转义 TEXT 类型的值。
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// fold 把超过 75 字节的内容行折行，不拆开 UTF-8 字符。
+func fold(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := utf8.RuneLen(r)
+		// 续行以一个空格开头，空格也计入长度
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/access"
+	"doubao-speech-service/internal/service/pagination"
+)
+
+// 待办状态筛选
+const (
+	StatusOpen = "open"
+	StatusDone = "done"
+	StatusAll  = "all"
+)
+
+// 待办列表排序方式
+const (
+	SortDue     = "due"     // 按截止日期升序，没有截止日期的排在最后
+	SortCreated = "created" // 按创建时间倒序
+)
+
+// Filter 待办列表和 ICS 导出共用的筛选条件。
+type Filter struct {
+	Status      string      // open / done / all
+	Assignee    string      // 负责人 UPN，为空表示不限
+	RequestId   string      // 只看某个任务的待办
+	WorkspaceId string      // 只看某个团队空间的待办
+	DueBefore   *gtime.Time // 截止日期早于（不含）
+	HasDue      bool        // 只看设置了截止日期的待办
+}
+
+// Query 返回用户可见的待办查询：可见任务中的待办，以及指派给用户的待办。
+// 查询别名为 t，关联的任务别名为 tr，可以读取任务标题和文件名。
+func Query(ctx context.Context, user access.User, f Filter) *gdb.Model {
+	cols := dao.Todo.Columns()
+	taskCols := dao.Transcription.Columns()
+	visible, args := access.VisibleCondition(ctx, user)
+	visibleIds := dao.Transcription.Ctx(ctx).Fields(taskCols.RequestId).Where(visible, args...)
+
+	m := dao.Todo.Ctx(ctx).As("t").
+		InnerJoin(dao.Transcription.Table()+" tr", fmt.Sprintf("tr.%s = t.%s", taskCols.RequestId, cols.RequestId)).
+		Where("(t."+cols.RequestId+" IN ? OR t."+cols.Assignee+" = ?)", visibleIds, user.ID)
+	switch f.Status {
+	case StatusOpen, "":
+		m = m.Where("NOT t." + cols.Done)
+	case StatusDone:
+		m = m.Where("t." + cols.Done)
+	}
+	if f.Assignee != "" {
+		m = m.Where("t."+cols.Assignee+" = ?", f.Assignee)
+	}
+	if f.RequestId != "" {
+		m = m.Where("t."+cols.RequestId+" = ?", f.RequestId)
+	}
+	if f.WorkspaceId != "" {
+		m = m.Where("tr."+taskCols.WorkspaceId+" = ?", f.WorkspaceId)
+	}
+	if f.HasDue {
+		m = m.WhereNotNull("t." + cols.DueDate)
+	}
+	if f.DueBefore != nil {
+		m = m.Where("t."+cols.DueDate+" < ?", f.DueBefore.Format("Y-m-d"))
+	}
+	return m
+}
+
+// Fields 返回列表查询的字段：待办的全部字段以及任务标题和文件名。
+func Fields() []any {
+	taskCols := dao.Transcription.Columns()
+	return []any{
+		"t.*",
+		"tr." + taskCols.Title + " AS task_title",
+		fmt.Sprintf("COALESCE(tr.%s->>'filename', '') AS file_name", taskCols.FileInfo),
+	}
+}
+
+// Keyset 返回排序方式对应的分页键集。
+func Keyset(sort string) pagination.Keyset {
+	cols := dao.Todo.Columns()
+	if sort == SortCreated {
+		return pagination.Keyset{Expr: "t." + cols.CreatedAt, Id: "t." + cols.Id, Desc: true}
+	}
+	return pagination.Keyset{Expr: "COALESCE(t." + cols.DueDate + ", 'infinity'::DATE)", Id: "t." + cols.Id}
+}
@@ -0,0 +1,175 @@
+// Package todo 把会议结果中的待办抽取为独立记录，支持标记完成、指派负责人、设置截止日期，以及跨会议查询和 ICS 导出。
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/guid"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+)
+
+// minSimilarity 是待办与转写句子关联所需的最低相似度
+const minSimilarity = 0.2
+
+// Extract 把结果中的待办写入 todo 表。已有记录按规范化后的内容与新的待办匹配，
+// 匹配上的记录更新上游字段和序号，保留用户修改过的负责人、截止日期和完成状态；
+// 结果中不再存在的待办会被删除。重新处理后待办的顺序或数量变化时，用户的修改仍跟着内容走。
+func Extract(ctx context.Context, requestId string, result *lark.Result) error {
+	if result == nil {
+		return nil
+	}
+	cols := dao.Todo.Columns()
+	return dao.Todo.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		var existing []*entity.Todo
+		if err := dao.Todo.Ctx(ctx).
+			Where(cols.RequestId+" = ?", requestId).
+			OrderAsc(cols.Seq).
+			Scan(&existing); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询待办失败")
+		}
+		// 内容相同的多条待办按顺序依次匹配
+		byContent := make(map[string][]*entity.Todo, len(existing))
+		for _, t := range existing {
+			key := normalize(t.Content)
+			byContent[key] = append(byContent[key], t)
+		}
+		var (
+			inserts []g.Map
+			updates = make(map[int64]g.Map, len(existing))
+		)
+		for seq, t := range result.Todos {
+			content := strings.TrimSpace(t.Content)
+			key := normalize(content)
+			if key == "" {
+				continue
+			}
+			sentenceId, start := locate(result, content)
+			data := g.Map{
+				cols.Seq:        seq,
+				cols.Content:    content,
+				cols.Executor:   t.Executor,
+				cols.Deadline:   t.Deadline,
+				cols.SentenceId: sentenceId,
+				cols.StartTime:  start,
+			}
+			if rows := byContent[key]; len(rows) > 0 {
+				byContent[key] = rows[1:]
+				updates[rows[0].Id] = data
+				continue
+			}
+			data[cols.TodoId] = guid.S()
+			data[cols.RequestId] = requestId
+			inserts = append(inserts, data)
+		}
+		var removed, kept []int64
+		for _, t := range existing {
+			if _, ok := updates[t.Id]; ok {
+				kept = append(kept, t.Id)
+			} else {
+				removed = append(removed, t.Id)
+			}
+		}
+		if len(removed) > 0 {
+			if _, err := dao.Todo.Ctx(ctx).WhereIn(cols.Id, removed).Delete(); err != nil {
+				return gerror.WrapCode(gcode.CodeDbOperationError, err, "清理待办失败")
+			}
+		}
+		// 序号在 (request_id, seq) 上唯一，先把保留的记录移到负数序号，避免互换序号时冲突
+		if len(kept) > 0 {
+			if _, err := dao.Todo.Ctx(ctx).
+				Data(fmt.Sprintf("%[1]s = -%[1]s - 1", cols.Seq)).
+				WhereIn(cols.Id, kept).
+				Update(); err != nil {
+				return gerror.WrapCode(gcode.CodeDbOperationError, err, "更新待办失败")
+			}
+		}
+		for _, id := range kept {
+			if _, err := dao.Todo.Ctx(ctx).Data(updates[id]).Where(cols.Id, id).Update(); err != nil {
+				return gerror.WrapCode(gcode.CodeDbOperationError, err, "更新待办失败")
+			}
+		}
+		if len(inserts) > 0 {
+			if _, err := dao.Todo.Ctx(ctx).Data(inserts).Insert(); err != nil {
+				return gerror.WrapCode(gcode.CodeDbOperationError, err, "保存待办失败")
+			}
+		}
+		return nil
+	})
+}
+
+// locate 找出与待办内容最相近的转写句子，按字符二元组的重合度计算。找不到时返回空的句子ID。
+func locate(result *lark.Result, content string) (string, int64) {
+	target := bigrams(content)
+	var (
+		best       float64
+		sentenceId string
+		start      int64
+	)
+	for _, u := range result.Utterances {
+		if score := similarity(target, bigrams(u.Content)); score > best {
+			best, sentenceId, start = score, u.SentenceId, u.StartTime
+		}
+	}
+	if best < minSimilarity {
+		return "", 0
+	}
+	return sentenceId, start
+}
+
+// normalize 转为小写并去掉空白和标点，用于比较待办内容。
+func normalize(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if !strings.ContainsRune(" \t\r\n，。、！？；：,.!?;:\"'“”‘’（）()", r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func bigrams(text string) map[string]struct{} {
+	runes := []rune(normalize(text))
+	out := make(map[string]struct{}, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		out[string(runes[i:i+2])] = struct{}{}
+	}
+	return out
+}
+
+// similarity 返回 target 中有多少比例的二元组出现在 candidate 中。
+func similarity(target, candidate map[string]struct{}) float64 {
+	if len(target) == 0 {
+		return 0
+	}
+	var hit int
+	for k := range target {
+		if _, ok := candidate[k]; ok {
+			hit++
+		}
+	}
+	return float64(hit) / float64(len(target))
+}
+
+// Get 按 todo_id 读取待办。不存在时返回 CodeNotFound 错误。
+func Get(ctx context.Context, todoId string) (*entity.Todo, error) {
+	var todo *entity.Todo
+	if err := dao.Todo.Ctx(ctx).
+		Where(dao.Todo.Columns().TodoId+" = ?", todoId).
+		Limit(1).
+		Scan(&todo); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询待办失败")
+	}
+	if todo == nil {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, "待办不存在：%s", todoId)
+	}
+	return todo, nil
+}
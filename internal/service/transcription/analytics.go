@@ -0,0 +1,60 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/service/analytics"
+)
+
+// Analytics 返回任务的对话统计。优先使用缓存在 transcription_result 中的结果，
+// 没有缓存（旧任务，或修正、说话人映射变化后被删除）时重新计算并写回。任务尚未完成时返回 nil。
+// 缓存不写任务表，读取统计不会改变任务的 updated_at 和 ETag。
+func Analytics(ctx context.Context, record *entity.Transcription) (*analytics.Analytics, error) {
+	if record.Version <= 0 {
+		return nil, nil
+	}
+	rows, err := loadResultFiles(ctx, record.RequestId, record.Version, KindAnalytics)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 {
+		var cached *analytics.Analytics
+		if err = rows[0].Content.Scan(&cached); err != nil {
+			return nil, gerror.Wrap(err, "解析对话统计失败")
+		}
+		return cached, nil
+	}
+
+	result, err := Result(ctx, record)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	computed := analytics.Compute(result, record.Duration)
+	stats := gjson.New(computed)
+	if err = saveResultFiles(ctx, record.RequestId, record.Version, map[string]ResultFile{
+		KindAnalytics: {Content: stats, Size: len(stats.MustToJson())},
+	}); err != nil {
+		// 缓存失败不影响返回结果
+		g.Log().Warningf(ctx, "[%s] 缓存对话统计失败：%v", record.RequestId, err)
+	}
+	return computed, nil
+}
+
+// invalidateAnalytics 删除各结果版本缓存的对话统计，下次读取时重新计算。
+func invalidateAnalytics(ctx context.Context, requestId string) error {
+	cols := dao.TranscriptionResult.Columns()
+	if _, err := dao.TranscriptionResult.Ctx(ctx).
+		Where(cols.RequestId+" = ?", requestId).
+		Where(cols.Kind+" = ?", KindAnalytics).
+		Delete(); err != nil {
+		return gerror.WrapCode(gcode.CodeDbOperationError, err, "清空对话统计失败")
+	}
+	return nil
+}
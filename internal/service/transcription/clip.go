@@ -0,0 +1,239 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"golang.org/x/sync/singleflight"
+
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/media"
+	"doubao-speech-service/internal/service/volcengine"
+)
+
+// clipFormat 是音频片段的输出格式
+type clipFormat struct {
+	ContentType string
+	Bitrate     string
+}
+
+var clipFormats = map[string]clipFormat{
+	"ogg": {ContentType: "audio/ogg", Bitrate: "64k"},
+	"mp3": {ContentType: "audio/mpeg", Bitrate: "96k"},
+	"wav": {ContentType: "audio/wav"},
+}
+
+// clipGroup 合并同一片段的并发生成请求
+var clipGroup singleflight.Group
+
+// ClipOptions 是截取音频片段的参数。按句子截取时忽略 Start、End。
+type ClipOptions struct {
+	Start        int64  // 毫秒
+	End          int64  // 毫秒
+	FromSentence string // 起始句子ID
+	ToSentence   string // 结束句子ID（包含），为空时与 FromSentence 相同
+	Format       string // ogg、mp3、wav
+}
+
+// Clip 是一个已生成并缓存在 TOS 中的音频片段。
+type Clip struct {
+	Key         string
+	Name        string // 下载文件名
+	ContentType string
+	Start       int64
+	End         int64
+	Size        int64
+	Sentences   []Sentence // 与片段时间区间重叠的句子，已应用转写修正和说话人映射
+}
+
+type clipConfig struct {
+	FFmpegPath  string
+	MaxDuration time.Duration // 单个片段的最长时长
+	Timeout     time.Duration // 生成一个片段的超时
+	URLExpires  time.Duration // 下载地址的有效期
+}
+
+func loadClipConfig(ctx context.Context) clipConfig {
+	tryFFMpeg, _ := exec.LookPath("ffmpeg")
+	return clipConfig{
+		FFmpegPath:  g.Cfg().MustGet(ctx, "media.clip.ffmpeg", tryFFMpeg).String(),
+		MaxDuration: g.Cfg().MustGet(ctx, "media.clip.maxDuration", "30m").Duration(),
+		Timeout:     g.Cfg().MustGet(ctx, "media.clip.timeout", "2m").Duration(),
+		URLExpires:  g.Cfg().MustGet(ctx, "media.clip.urlExpires", "1h").Duration(),
+	}
+}
+
+// MakeClip 截取任务原始音频的一个片段。同一区间和格式的片段只生成一次，之后直接使用 TOS 中的缓存。
+// result 用于按句子定位和摘录转写，任务尚未完成时可以为 nil，此时只能按时间截取。
+func MakeClip(ctx context.Context, record *entity.Transcription, result *lark.Result, opts ClipOptions) (*Clip, error) {
+	format, ok := clipFormats[opts.Format]
+	if !ok {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "不支持的音频格式：%s，可选 ogg、mp3、wav", opts.Format)
+	}
+	cfg := loadClipConfig(ctx)
+	start, end, err := clipRange(record, result, opts)
+	if err != nil {
+		return nil, err
+	}
+	if time.Duration(end-start)*time.Millisecond > cfg.MaxDuration {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "片段不能超过 %s", cfg.MaxDuration)
+	}
+
+	clip := &Clip{
+		Key:         volcengine.ClipKey(record.RequestId, fmt.Sprintf("%d-%d.%s", start, end, opts.Format)),
+		Name:        clipName(record, start, end, opts.Format),
+		ContentType: format.ContentType,
+		Start:       start,
+		End:         end,
+	}
+	if result != nil {
+		for _, u := range result.Utterances {
+			if u.EndTime > start && u.StartTime < end {
+				clip.Sentences = append(clip.Sentences, newSentence(result, u))
+			}
+		}
+	}
+
+	// 原始音频上传后不会改变，缓存的片段一直有效
+	size, err, _ := clipGroup.Do(clip.Key, func() (any, error) {
+		// 生成结果由同一片段的所有请求共享，不随发起请求的客户端断开而取消，只受生成超时限制
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.Timeout)
+		defer cancel()
+		info, err := volcengine.StatObject(ctx, clip.Key)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			return info.Size, nil
+		}
+		return generateClip(ctx, cfg, record, clip, opts.Format, format)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clip.Size = size.(int64)
+	return clip, nil
+}
+
+// ClipURL 返回片段的预签名下载地址及其过期时间。
+func ClipURL(ctx context.Context, clip *Clip) (string, time.Time, error) {
+	expires := loadClipConfig(ctx).URLExpires
+	url, err := volcengine.SignedURL(ctx, clip.Key, int64(expires.Seconds()), clip.Name)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return url, time.Now().Add(expires), nil
+}
+
+// OpenClip 读取片段内容，调用方负责关闭。
+func OpenClip(ctx context.Context, clip *Clip) (io.ReadCloser, error) {
+	return volcengine.OpenObject(ctx, clip.Key)
+}
+
+// clipRange 确定片段的时间区间（毫秒），结束时间不超过音频时长。
+func clipRange(record *entity.Transcription, result *lark.Result, opts ClipOptions) (int64, int64, error) {
+	start, end := opts.Start, opts.End
+	if opts.FromSentence != "" {
+		if result == nil {
+			return 0, 0, gerror.NewCode(gcode.CodeInvalidOperation, "任务尚未完成，只能按时间截取")
+		}
+		to := opts.ToSentence
+		if to == "" {
+			to = opts.FromSentence
+		}
+		from, last := -1, -1
+		for i, u := range result.Utterances {
+			if u.SentenceId == opts.FromSentence {
+				from = i
+			}
+			if u.SentenceId == to {
+				last = i
+			}
+		}
+		if from < 0 {
+			return 0, 0, gerror.NewCodef(gcode.CodeNotFound, "句子不存在：%s", opts.FromSentence)
+		}
+		if last < 0 {
+			return 0, 0, gerror.NewCodef(gcode.CodeNotFound, "句子不存在：%s", to)
+		}
+		if last < from {
+			return 0, 0, gerror.NewCode(gcode.CodeInvalidParameter, "结束句子不能在起始句子之前")
+		}
+		start, end = result.Utterances[from].StartTime, result.Utterances[last].EndTime
+		for _, u := range result.Utterances[from : last+1] {
+			start, end = min(start, u.StartTime), max(end, u.EndTime)
+		}
+	}
+	if record.Duration > 0 && end > record.Duration {
+		end = record.Duration
+	}
+	if start < 0 || end <= start {
+		return 0, 0, gerror.NewCode(gcode.CodeInvalidParameter, "结束时间必须晚于开始时间")
+	}
+	return start, end, nil
+}
+
+// clipName 生成下载文件名，例如 周例会_01-02-03_01-05-00.mp3。
+func clipName(record *entity.Transcription, start, end int64, format string) string {
+	base := record.Title
+	if base == "" {
+		filename := record.FileInfo.Get("filename").String()
+		base = strings.TrimSuffix(filename, filepath.Ext(filename))
+	}
+	if base == "" {
+		base = record.RequestId
+	}
+	return fmt.Sprintf("%s_%s_%s.%s", base, clipClock(start), clipClock(end), format)
+}
+
+func clipClock(ms int64) string {
+	s := ms / 1000
+	return fmt.Sprintf("%02d-%02d-%02d", s/3600, s/60%60, s%60)
+}
+
+// generateClip 用 ffmpeg 从原始音频的预签名地址截取片段，上传到 TOS 并返回片段大小。
+func generateClip(ctx context.Context, cfg clipConfig, record *entity.Transcription, clip *Clip, ext string, format clipFormat) (int64, error) {
+	if cfg.FFmpegPath == "" {
+		return 0, gerror.NewCode(gcode.CodeNotSupported, "未配置 ffmpeg，无法截取音频")
+	}
+	source, err := volcengine.GetFileURL(ctx, record)
+	if err != nil {
+		return 0, err
+	}
+	file, err := os.CreateTemp("", "clip-*."+ext)
+	if err != nil {
+		return 0, gerror.Wrap(err, "创建临时文件失败")
+	}
+	_ = file.Close()
+	defer os.Remove(file.Name())
+
+	converter := media.NewConverter(cfg.FFmpegPath, media.ConvertOptions{
+		TargetFormat: ext,
+		AudioBitrate: format.Bitrate,
+	})
+	start := time.Duration(clip.Start) * time.Millisecond
+	duration := time.Duration(clip.End-clip.Start) * time.Millisecond
+	if err = converter.Clip(ctx, source, file.Name(), start, duration); err != nil {
+		// ffmpeg 的输出中带有预签名地址，只写日志
+		g.Log().Errorf(ctx, "[%s] 截取音频 %s 失败：%v", record.RequestId, clip.Key, err)
+		return 0, gerror.NewCode(gcode.CodeInternalError, "截取音频失败")
+	}
+	info, err := os.Stat(file.Name())
+	if err != nil {
+		return 0, gerror.Wrap(err, "读取音频片段失败")
+	}
+	if err = volcengine.PutFile(ctx, clip.Key, file.Name(), format.ContentType); err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,54 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/conditional"
+)
+
+// touch 增加任务的修改计数，使任务的 ETag 失效。任务记录之外的数据（转写修正、说话人映射）变化时调用。
+func touch(ctx context.Context, requestId string) error {
+	cols := dao.Transcription.Columns()
+	if _, err := dao.Transcription.Ctx(ctx).
+		Data(g.Map{cols.ChangeSeq: gdb.Raw(cols.ChangeSeq + " + 1")}).
+		Where(cols.RequestId+" = ?", requestId).
+		Update(); err != nil {
+		return gerror.WrapCode(gcode.CodeDbOperationError, err, "更新任务修改计数失败")
+	}
+	return nil
+}
+
+// ETag 返回任务当前状态的强 ETag，由 updated_at 和修改计数计算。
+// variant 区分同一任务的不同表示，通常是请求的路径和查询参数。任务不存在时返回 CodeNotFound 错误。
+func ETag(ctx context.Context, requestId, variant string) (string, error) {
+	cols := dao.Transcription.Columns()
+	row, err := dao.Transcription.Ctx(ctx).
+		Fields(cols.UpdatedAt, cols.ChangeSeq).
+		Where(cols.RequestId+" = ?", requestId).
+		One()
+	if err != nil {
+		return "", gerror.WrapCode(gcode.CodeDbOperationError, err, "查询任务记录失败")
+	}
+	if row.IsEmpty() {
+		return "", gerror.NewCode(gcode.CodeNotFound, "任务不存在")
+	}
+	return conditional.Tag(requestId, row[cols.UpdatedAt].GTime().UnixMicro(), row[cols.ChangeSeq].Int64(), variant), nil
+}
+
+// ListETag 返回任务列表一页的 ETag，由每个任务的 updated_at、修改计数以及分页信息计算。
+// records 需要包含 MetaFields 中的字段。
+func ListETag(records gdb.Result, variant string, extra ...any) string {
+	cols := dao.Transcription.Columns()
+	parts := make([]any, 0, len(records)*3+len(extra)+1)
+	parts = append(parts, variant)
+	for _, r := range records {
+		parts = append(parts, r[cols.RequestId].String(), r[cols.UpdatedAt].GTime().UnixMicro(), r[cols.ChangeSeq].Int64())
+	}
+	return conditional.Tag(append(parts, extra...)...)
+}
@@ -0,0 +1,193 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/volcengine"
+)
+
+// 任务状态。running、success、failed 等由火山云返回，partial_success 表示部分结果文件获取失败
+const (
+	StatusSuccess        = "success"
+	StatusPartialSuccess = "partial_success"
+)
+
+// FetchError 是一个结果文件获取失败的原因。
+type FetchError struct {
+	Attempts int    `json:"attempts"`
+	Status   int    `json:"status"` // 最后一次请求的 HTTP 状态码，请求未完成时为 0
+	Message  string `json:"message"`
+}
+
+func (e *FetchError) Error() string {
+	return e.Message
+}
+
+// ArchivedFile 是归档到 TOS 的原始结果文件。
+type ArchivedFile struct {
+	Key       string      `json:"key"`
+	Size      int         `json:"size"`
+	FetchedAt *gtime.Time `json:"fetchedAt"`
+}
+
+// fetchConfig 是结果文件下载的配置，读取 volc.lark.fetch.*。
+type fetchConfig struct {
+	Retries int           // 失败后重试次数
+	Backoff time.Duration // 第一次重试前的等待时间，之后每次翻倍
+	Timeout time.Duration // 单次请求超时
+	Archive bool          // 是否归档原始结果文件
+
+	Refetches       int           // 部分文件下载失败（partial_success）后重新查询的次数
+	RefetchInterval time.Duration // 第一次重新查询前的等待时间，之后每次翻倍
+}
+
+func loadFetchConfig(ctx context.Context) fetchConfig {
+	return fetchConfig{
+		Retries: g.Cfg().MustGet(ctx, "volc.lark.fetch.retries", 3).Int(),
+		Backoff: g.Cfg().MustGet(ctx, "volc.lark.fetch.backoff", "1s").Duration(),
+		Timeout: g.Cfg().MustGet(ctx, "volc.lark.fetch.timeout", "30s").Duration(),
+		Archive: g.Cfg().MustGet(ctx, "volc.lark.fetch.archive", true).Bool(),
+
+		Refetches:       g.Cfg().MustGet(ctx, "volc.lark.fetch.refetches", 3).Int(),
+		RefetchInterval: g.Cfg().MustGet(ctx, "volc.lark.fetch.refetchInterval", "5m").Duration(),
+	}
+}
+
+// fetchFile 下载一个结果文件并校验格式。网络错误、5xx 和 429 会按指数退避重试；
+// 4xx（例如下载链接过期）和格式错误不重试。
+func fetchFile(ctx context.Context, cfg fetchConfig, name, url string) ([]byte, *gjson.Json, *FetchError) {
+	var (
+		fetchErr = &FetchError{}
+		wait     = cfg.Backoff
+	)
+	for attempt := 0; attempt <= cfg.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				fetchErr.Message = "下载被取消：" + ctx.Err().Error()
+				return nil, nil, fetchErr
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+		fetchErr.Attempts = attempt + 1
+
+		body, status, err := get(ctx, cfg.Timeout, url)
+		fetchErr.Status = status
+		if err != nil {
+			fetchErr.Message = err.Error()
+			continue
+		}
+		if status != http.StatusOK {
+			fetchErr.Message = gerror.Newf("HTTP %d：%s", status, preview(body)).Error()
+			if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+				continue
+			}
+			return nil, nil, fetchErr
+		}
+		j, err := validateFile(name, body)
+		if err != nil {
+			fetchErr.Message = err.Error()
+			return nil, nil, fetchErr
+		}
+		return body, j, nil
+	}
+	return nil, nil, fetchErr
+}
+
+func get(ctx context.Context, timeout time.Duration, url string) ([]byte, int, error) {
+	r, err := g.Client().Timeout(timeout).Get(ctx, url)
+	if err != nil {
+		return nil, 0, gerror.Wrap(err, "下载失败")
+	}
+	defer r.Close()
+	return r.ReadAll(), r.StatusCode, nil
+}
+
+// validateFile 校验结果文件是 JSON，且是预期的形状：语音转写是句子数组（或包裹着数组的对象），其余文件是非空的对象或数组。
+func validateFile(name string, body []byte) (*gjson.Json, error) {
+	if !json.Valid(body) {
+		return nil, gerror.Newf("结果文件不是合法的 JSON：%s", preview(body))
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, gerror.Wrap(err, "解析结果文件失败")
+	}
+	switch t := v.(type) {
+	case []any:
+		if name == KindAudioTranscription && len(t) > 0 {
+			if _, ok := t[0].(map[string]any); !ok {
+				return nil, gerror.New("语音转写结果的元素不是对象")
+			}
+		}
+	case map[string]any:
+		if len(t) == 0 {
+			return nil, gerror.New("结果文件为空对象")
+		}
+		if name == KindAudioTranscription && !containsArray(t) {
+			return nil, gerror.New("语音转写结果中没有句子数组")
+		}
+	default:
+		return nil, gerror.Newf("结果文件的顶层不是对象或数组：%s", preview(body))
+	}
+	return gjson.New(body), nil
+}
+
+func containsArray(m map[string]any) bool {
+	for _, v := range m {
+		if _, ok := v.([]any); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func preview(body []byte) string {
+	s := string(body)
+	if len(s) > 200 {
+		s = gstr.SubStr(s, 0, 200) + "..."
+	}
+	return s
+}
+
+// archiveFile 把原始结果文件归档到 TOS，失败只记录日志。
+func archiveFile(ctx context.Context, requestId, taskId, name string, body []byte) *ArchivedFile {
+	key, err := volcengine.ArchiveResult(ctx, requestId, taskId, name, body)
+	if err != nil {
+		g.Log().Warningf(ctx, "[%s] 归档结果文件 %s 失败：%v", requestId, name, err)
+		return nil
+	}
+	return &ArchivedFile{Key: key, Size: len(body), FetchedAt: gtime.Now()}
+}
+
+// mergeArchives 把本次归档的文件合并到同一结果版本已有的归档记录中，保留本次下载失败的文件之前的归档。
+func mergeArchives(ctx context.Context, taskId string, archives map[string]*ArchivedFile) (map[string]*ArchivedFile, error) {
+	cols := dao.TranscriptionVersion.Columns()
+	value, err := dao.TranscriptionVersion.Ctx(ctx).
+		Fields(cols.ResultArchive).
+		Where(cols.TaskId+" = ?", taskId).
+		Value()
+	if err != nil {
+		return archives, gerror.Wrap(err, "查询结果文件归档记录失败")
+	}
+	merged := map[string]*ArchivedFile{}
+	if !value.IsNil() && value.String() != "" {
+		if err = gjson.New(value.String()).Scan(&merged); err != nil {
+			return archives, gerror.Wrap(err, "解析结果文件归档记录失败")
+		}
+	}
+	for k, v := range archives {
+		merged[k] = v
+	}
+	return merged, nil
+}
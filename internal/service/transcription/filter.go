@@ -0,0 +1,141 @@
+package transcription
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/service/pagination"
+)
+
+const (
+	MaxTags      = 20 // 每个任务最多的标签数
+	MaxTagLength = 32 // 每个标签最长的字符数
+)
+
+// 功能名称与任务参数 Params 中开关字段的对应关系
+var featureParams = map[string]string{
+	"translation":            "TranslationEnable",
+	"summarization":          "SummarizationEnabled",
+	"chapter":                "ChapterEnabled",
+	"information_extraction": "InformationExtractionEnabled",
+}
+
+// TaskFilter 任务列表和搜索共用的筛选条件。
+type TaskFilter struct {
+	Status      []string    // 任务状态，满足其一即可
+	CreatedFrom *gtime.Time // 创建时间起（含）
+	CreatedTo   *gtime.Time // 创建时间止（不含）
+	FileType    string      // audio / video
+	DurationMin int64       // 最短时长（秒）
+	DurationMax int64       // 最长时长（秒），0 表示不限
+	Features    []string    // 需同时开启的功能，见 featureParams
+	Folder      string      // 文件夹，精确匹配
+	Tags        []string    // 标签，需同时包含所有标签
+}
+
+// Apply 把筛选条件加到 transcription 表的查询上。
+func (f TaskFilter) Apply(m *gdb.Model) *gdb.Model {
+	cols := dao.Transcription.Columns()
+	if len(f.Status) > 0 {
+		m = m.WhereIn(cols.Status, f.Status)
+	}
+	if f.CreatedFrom != nil {
+		m = m.WhereGTE(cols.CreatedAt, f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		m = m.WhereLT(cols.CreatedAt, f.CreatedTo)
+	}
+	if f.FileType != "" {
+		m = m.Where(fmt.Sprintf("%s->'Input'->'Offline'->>'FileType' = ?", cols.TaskParams), f.FileType)
+	}
+	if f.DurationMin > 0 {
+		m = m.Where(cols.Duration+" >= ?", f.DurationMin*1000)
+	}
+	if f.DurationMax > 0 {
+		m = m.Where(cols.Duration+" <= ?", f.DurationMax*1000)
+	}
+	for _, feature := range f.Features {
+		param, ok := featureParams[feature]
+		if !ok {
+			continue
+		}
+		// 打包计费（AllActivate）时所有功能都会开启
+		m = m.Where(fmt.Sprintf(
+			"(%[1]s->'Params'->>'%[2]s' = 'true' OR %[1]s->'Params'->>'AllActivate' = 'true')",
+			cols.TaskParams, param,
+		))
+	}
+	if f.Folder != "" {
+		m = m.Where(cols.Folder+" = ?", f.Folder)
+	}
+	if tags := compactTags(f.Tags); len(tags) > 0 {
+		m = m.Where(cols.Tags+" @> ?::jsonb", gjson.MustEncodeString(tags))
+	}
+	return m
+}
+
+// NormalizeTags 去除标签首尾空白、空标签和重复标签，并检查数量和长度限制。
+func NormalizeTags(tags []string) ([]string, error) {
+	result := compactTags(tags)
+	if len(result) > MaxTags {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "标签最多 %d 个", MaxTags)
+	}
+	for _, tag := range result {
+		if len([]rune(tag)) > MaxTagLength {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "标签最长 %d 个字符：%s", MaxTagLength, tag)
+		}
+	}
+	return result, nil
+}
+
+func compactTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// TaskSort 任务列表的排序方式。排序始终以 id 作为第二排序键，保证分页稳定。
+type TaskSort struct {
+	By   string // created_at / duration / title
+	Desc bool
+}
+
+// Expr 返回排序字段对应的 SQL 表达式。需与 migrations 中的表达式索引保持一致。
+func (s TaskSort) Expr() string {
+	cols := dao.Transcription.Columns()
+	switch s.By {
+	case "duration":
+		return fmt.Sprintf("COALESCE(%s, 0)", cols.Duration)
+	case "title":
+		return fmt.Sprintf("COALESCE(NULLIF(%s, ''), %s->>'filename', '')", cols.Title, cols.FileInfo)
+	default:
+		return cols.CreatedAt
+	}
+}
+
+// Keyset 返回用于键集分页的排序方式。
+func (s TaskSort) Keyset() pagination.Keyset {
+	return pagination.Keyset{
+		Expr: s.Expr(),
+		Id:   dao.Transcription.Columns().Id,
+		Desc: s.Desc,
+	}
+}
@@ -0,0 +1,184 @@
+package transcription
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/export"
+)
+
+// 句内查找的匹配方式
+const (
+	FindModeExact      = "exact"       // 区分大小写的精确匹配
+	FindModeIgnoreCase = "ignore_case" // 不区分大小写
+	FindModeRegex      = "regex"       // Go 正则（RE2），可用 (?i) 忽略大小写
+)
+
+// 查找的字段
+const (
+	FindFieldContent     = "content"
+	FindFieldTranslation = "translation"
+)
+
+// maxFindPattern 是关键词 / 正则的最大长度
+const maxFindPattern = 200
+
+// FindOptions 是句内查找的参数。
+type FindOptions struct {
+	Query       string
+	Mode        string
+	Translation bool // 同时查找译文
+	Context     int  // 前后各返回几句上下文
+	Limit       int  // 最多返回的命中句数，<= 0 表示不限
+}
+
+// Sentence 是带说话人名称的一句转写，用于查找结果和音频片段的转写摘录。
+type Sentence struct {
+	SentenceId  string
+	SpeakerId   string
+	Speaker     string
+	Content     string
+	Translation string
+	StartTime   int64
+	EndTime     int64
+}
+
+func newSentence(result *lark.Result, u lark.Utterance) Sentence {
+	return Sentence{
+		SentenceId: u.SentenceId,
+		SpeakerId:  u.SpeakerId,
+		Speaker:    export.SpeakerName(result, u.SpeakerId),
+		Content:    u.Content,
+		StartTime:  u.StartTime,
+		EndTime:    u.EndTime,
+	}
+}
+
+// Found 是一句命中的转写。Offsets 为命中区间 [start, end)，按 Unicode 字符计。
+type Found struct {
+	Sentence
+	Field   string   // 命中的字段：content 或 translation
+	Offsets [][2]int // 命中区间
+	Before  []Sentence
+	After   []Sentence
+}
+
+// Find 在结果的转写（以及译文）中查找，返回所有命中句中的前 Limit 句和命中总句数。
+// 同一句的原文和译文都命中时分别返回。
+func Find(result *lark.Result, opts FindOptions) ([]Found, int, error) {
+	match, err := matcher(trimQuery(opts.Query, opts.Mode), opts.Mode)
+	if err != nil {
+		return nil, 0, err
+	}
+	if result == nil {
+		return []Found{}, 0, nil
+	}
+
+	var translations []string
+	if opts.Translation {
+		translations = export.AlignTranslations(result)
+	}
+	sentences := make([]Sentence, len(result.Utterances))
+	for i, u := range result.Utterances {
+		sentences[i] = newSentence(result, u)
+		if translations != nil {
+			sentences[i].Translation = translations[i]
+		}
+	}
+
+	var (
+		found = []Found{}
+		total int
+	)
+	collect := func(i int, field, text string) {
+		offsets := match(text)
+		if len(offsets) == 0 {
+			return
+		}
+		total++
+		if opts.Limit > 0 && len(found) >= opts.Limit {
+			return
+		}
+		found = append(found, Found{
+			Sentence: sentences[i],
+			Field:    field,
+			Offsets:  offsets,
+			Before:   sentences[max(0, i-opts.Context):i],
+			After:    sentences[i+1 : min(len(sentences), i+1+opts.Context)],
+		})
+	}
+	for i, s := range sentences {
+		collect(i, FindFieldContent, s.Content)
+		if opts.Translation {
+			collect(i, FindFieldTranslation, s.Translation)
+		}
+	}
+	return found, total, nil
+}
+
+// matcher 按匹配方式返回查找函数，查找函数返回按字符计的命中区间。
+func matcher(query, mode string) (func(string) [][2]int, error) {
+	if query == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "关键词不能为空")
+	}
+	if utf8.RuneCountInString(query) > maxFindPattern {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "关键词不能超过 %d 个字符", maxFindPattern)
+	}
+	switch mode {
+	case FindModeExact, "":
+		key := []rune(query)
+		return func(text string) [][2]int {
+			return runeMatches([]rune(text), key)
+		}, nil
+	case FindModeIgnoreCase:
+		key := foldRunes([]rune(query))
+		return func(text string) [][2]int {
+			return runeMatches(foldRunes([]rune(text)), key)
+		}, nil
+	case FindModeRegex:
+		re, err := regexp.Compile(query)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "正则表达式无效")
+		}
+		return func(text string) [][2]int {
+			var out [][2]int
+			for _, loc := range re.FindAllStringIndex(text, -1) {
+				// 跳过空匹配，例如 a*
+				if loc[0] == loc[1] {
+					continue
+				}
+				start := utf8.RuneCountInString(text[:loc[0]])
+				out = append(out, [2]int{start, start + utf8.RuneCountInString(text[loc[0]:loc[1]])})
+			}
+			return out
+		}, nil
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "不支持的匹配方式：%s", mode)
+	}
+}
+
+// runeMatches 返回 key 在 text 中所有不重叠出现的区间。
+func runeMatches(text, key []rune) [][2]int {
+	var out [][2]int
+	for i := 0; ; {
+		j := indexRunes(text, key, i)
+		if j < 0 {
+			return out
+		}
+		out = append(out, [2]int{j, j + len(key)})
+		i = j + len(key)
+	}
+}
+
+// trimQuery 去掉查询两端的空白，正则模式下保留原样。
+func trimQuery(query, mode string) string {
+	if mode == FindModeRegex {
+		return query
+	}
+	return strings.TrimSpace(query)
+}
@@ -0,0 +1,58 @@
+package transcription
+
+import (
+	"context"
+	"mime"
+	"path/filepath"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/service/volcengine"
+)
+
+// Media 是任务的原始音频，Reader 按需从 TOS 读取，调用方负责关闭。
+type Media struct {
+	Name        string
+	ContentType string
+	Info        *volcengine.ObjectInfo
+	Reader      *volcengine.ObjectReader
+}
+
+// MediaRedirectURL 在配置了 media.proxy.redirect 时返回原始音频的预签名地址，否则返回空字符串，由服务端代理播放。
+func MediaRedirectURL(ctx context.Context, record *entity.Transcription) (string, error) {
+	if !g.Cfg().MustGet(ctx, "media.proxy.redirect", false).Bool() {
+		return "", nil
+	}
+	expires := g.Cfg().MustGet(ctx, "media.proxy.urlExpires", "6h").Duration()
+	return volcengine.SignedURL(ctx, volcengine.FileKey(record), int64(expires/time.Second), "")
+}
+
+// OpenMedia 打开任务的原始音频。
+func OpenMedia(ctx context.Context, record *entity.Transcription) (*Media, error) {
+	name := record.FileInfo.Get("filename").String()
+	if name == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "任务没有音频文件")
+	}
+	key := volcengine.FileKey(record)
+	info, err := volcengine.StatObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "音频文件不存在")
+	}
+	contentType := info.ContentType
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = mime.TypeByExtension(filepath.Ext(name))
+	}
+	return &Media{
+		Name:        name,
+		ContentType: contentType,
+		Info:        info,
+		Reader:      volcengine.NewObjectReader(ctx, key, info),
+	}, nil
+}
@@ -0,0 +1,198 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+)
+
+// 任务详情中可以单独选择的部分
+const (
+	PartMeta          = "meta"          // 任务元数据
+	PartTranscription = "transcription" // audioTranscriptionFile
+	PartChapters      = "chapters"      // chapterFile
+	PartInformation   = "information"   // informationExtractionFile
+	PartSummary       = "summary"       // summarizationFile
+	PartTranslation   = "translation"   // translationFile
+)
+
+// partKinds 是各部分对应的结果文件种类
+var partKinds = map[string]string{
+	PartTranscription: KindAudioTranscription,
+	PartChapters:      KindChapter,
+	PartInformation:   KindInformationExtraction,
+	PartSummary:       KindSummarization,
+	PartTranslation:   KindTranslation,
+}
+
+// Parts 是任务详情要返回的部分。
+type Parts map[string]bool
+
+// SelectParts 解析逗号分隔的 fields 和 exclude 参数。fields 为空表示全部部分，exclude 在 fields 的基础上排除。
+func SelectParts(fields, exclude string) (Parts, error) {
+	parts := Parts{}
+	include, err := splitParts(fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(include) == 0 {
+		parts[PartMeta] = true
+		for part := range partKinds {
+			parts[part] = true
+		}
+	}
+	for _, part := range include {
+		parts[part] = true
+	}
+	excluded, err := splitParts(exclude)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range excluded {
+		delete(parts, part)
+	}
+	return parts, nil
+}
+
+func splitParts(s string) ([]string, error) {
+	var parts []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if _, ok := partKinds[part]; !ok && part != PartMeta {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "未知的字段：%s，可选 meta、transcription、chapters、information、summary、translation", part)
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// Kinds 返回选中部分对应的结果文件种类，except 中的种类除外。
+func (p Parts) Kinds(except ...string) []string {
+	var kinds []string
+	for part, kind := range partKinds {
+		if p[part] && !gstr.InArray(except, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// SentenceRange 是语音转写句子的分页条件：先按时间区间筛选，再取 offset 之后的 limit 句。
+type SentenceRange struct {
+	Offset    int
+	Limit     int   // 0 表示不限
+	StartTime int64 // 毫秒，只返回结束时间晚于它的句子；0 表示不限
+	EndTime   int64 // 毫秒，只返回开始时间早于它的句子；0 表示不限
+}
+
+// IsZero 表示没有分页条件，返回完整的语音转写。
+func (r SentenceRange) IsZero() bool {
+	return r == SentenceRange{}
+}
+
+// SentencePage 是一页语音转写句子。
+type SentencePage struct {
+	Content *gjson.Json // 句子数组
+	Total   int         // 满足时间区间的句子总数
+}
+
+// TranscriptionPage 截取当前版本语音转写的一页句子，并应用转写修正。筛选和分页都基于修正后的句子，按开始时间排序。
+// 没有修改句子时间的修正时在数据库中截取，不读取完整的转写；否则读取完整转写，应用修正后再截取。
+// 语音转写不是句子数组（旧格式）或尚未产生时返回 nil，调用方应退回读取完整文件。
+func TranscriptionPage(ctx context.Context, record *entity.Transcription, r SentenceRange, corrections []lark.Correction) (*SentencePage, error) {
+	if record.Version <= 0 {
+		return nil, nil
+	}
+	for _, c := range corrections {
+		if c.StartTime != nil || c.EndTime != nil {
+			return correctedPage(ctx, record, r, corrections)
+		}
+	}
+	var (
+		cols  = dao.TranscriptionResult.Columns()
+		conds = []string{"TRUE"}
+		args  []any
+	)
+	if r.StartTime > 0 {
+		conds = append(conds, "(s.e->>'end_time')::BIGINT > ?")
+		args = append(args, r.StartTime)
+	}
+	if r.EndTime > 0 {
+		conds = append(conds, "(s.e->>'start_time')::BIGINT < ?")
+		args = append(args, r.EndTime)
+	}
+	limit := "ALL"
+	if r.Limit > 0 {
+		limit = fmt.Sprint(r.Limit)
+	}
+	filtered := fmt.Sprintf(
+		"SELECT s.e, s.ord FROM jsonb_array_elements(%s) WITH ORDINALITY AS s(e, ord) WHERE %s",
+		cols.Content, strings.Join(conds, " AND "),
+	)
+	query := fmt.Sprintf(
+		"SELECT (SELECT COUNT(*) FROM (%[1]s) f) AS total, "+
+			"(SELECT COALESCE(jsonb_agg(p.e ORDER BY (p.e->>'start_time')::BIGINT, p.ord), '[]'::JSONB) "+
+			"FROM (%[1]s ORDER BY (s.e->>'start_time')::BIGINT, s.ord OFFSET %[2]d LIMIT %[3]s) p) AS content "+
+			"FROM %[4]s WHERE %[5]s = ? AND %[6]s = ? AND %[7]s = ? AND jsonb_typeof(%[8]s) = 'array'",
+		filtered, r.Offset, limit,
+		dao.TranscriptionResult.Table(), cols.RequestId, cols.Version, cols.Kind, cols.Content,
+	)
+	// 两个子查询各用一次时间条件
+	args = append(append(args, args...), record.RequestId, record.Version, KindAudioTranscription)
+
+	row, err := dao.TranscriptionResult.DB().Raw(query, args...).Ctx(ctx).One()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "读取语音转写失败")
+	}
+	if row.IsEmpty() {
+		return nil, nil
+	}
+	return &SentencePage{
+		Content: ApplyCorrectionsToRaw(gjson.New(row["content"].String()), corrections),
+		Total:   row["total"].Int(),
+	}, nil
+}
+
+// correctedPage 读取完整的语音转写，应用修正并按开始时间排序后截取一页。修正改变了句子时间时使用。
+func correctedPage(ctx context.Context, record *entity.Transcription, r SentenceRange, corrections []lark.Correction) (*SentencePage, error) {
+	files, err := ResultFiles(ctx, record, KindAudioTranscription)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := ApplyCorrectionsToRaw(files.AudioTranscription, corrections).Interface().([]any)
+	if !ok {
+		return nil, nil
+	}
+	filtered := make([]any, 0, len(items))
+	for _, item := range items {
+		m, _ := item.(map[string]any)
+		if r.StartTime > 0 && gconv.Int64(m["end_time"]) <= r.StartTime {
+			continue
+		}
+		if r.EndTime > 0 && gconv.Int64(m["start_time"]) >= r.EndTime {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+	page := filtered[min(r.Offset, len(filtered)):]
+	if r.Limit > 0 && r.Limit < len(page) {
+		page = page[:r.Limit]
+	}
+	return &SentencePage{
+		Content: gjson.New(page),
+		Total:   len(filtered),
+	}, nil
+}
@@ -14,6 +14,11 @@ func Polling(taskId, requestId string) {
 		t := time.NewTicker(30 * time.Second)
 		defer t.Stop()
 
+		var (
+			cfg       = loadFetchConfig(bgCtx)
+			refetches int
+			wait      = cfg.RefetchInterval
+		)
 		for range t.C {
 			status, err := Query(bgCtx, taskId, requestId)
 			if err != nil {
@@ -23,6 +28,18 @@ func Polling(taskId, requestId string) {
 			if status == "running" {
 				continue
 			}
+			// 部分结果文件下载失败时按退避间隔重新查询，火山云会返回新的下载链接，补齐失败的文件
+			if status == StatusPartialSuccess && refetches < cfg.Refetches {
+				refetches++
+				g.Log().Infof(bgCtx, "[%s] 任务 %s 部分结果文件下载失败，%s 后第 %d 次重新获取", requestId, taskId, wait, refetches)
+				select {
+				case <-bgCtx.Done():
+					return
+				case <-time.After(wait):
+				}
+				wait *= 2
+				continue
+			}
 			g.Log().Infof(bgCtx, "[%s] 任务 %s 轮询结束。最终状态：%s", requestId, taskId, status)
 			break
 		}
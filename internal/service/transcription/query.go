@@ -7,6 +7,9 @@ import (
 
 	"doubao-speech-service/internal/consts"
 	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/analytics"
+	"doubao-speech-service/internal/service/todo"
 
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -32,8 +35,11 @@ type QueryRes struct {
 }
 
 type FetchResult struct {
-	Key    string
-	Result *gjson.Json
+	Key     string
+	Result  *gjson.Json
+	Size    int
+	Err     *FetchError
+	Archive *ArchivedFile
 }
 
 func Query(ctx context.Context, taskId string, requestId string) (string, error) {
@@ -86,22 +92,33 @@ func Query(ctx context.Context, taskId string, requestId string) (string, error)
 	if err = gconv.Struct(bodyStr, &queryRes); err != nil {
 		return "", gerror.Wrap(err, "返回结果格式化失败")
 	}
+	// 任务记录上只保存当前版本的结果，按 task_id 更新时重新处理中的版本不会覆盖当前结果
+	version, err := versionByTask(ctx, taskId)
+	if err != nil {
+		return "", err
+	}
+	if version == nil {
+		return "", gerror.Newf("任务 %s 没有对应的结果版本", taskId)
+	}
 	if queryRes.Data.Status != "success" {
 		dao.Transcription.Ctx(ctx).Data(g.Map{
 			"status": queryRes.Data.Status,
 		}).Where("task_id = ? and request_id = ?", taskId, requestId).Update()
+		dao.TranscriptionVersion.Ctx(ctx).Data(g.Map{
+			"status": queryRes.Data.Status,
+		}).Where("task_id = ?", taskId).Update()
 	} else {
-		// 成功了
+		// 成功了。并发下载结果文件，失败的文件记录原因，任务标记为 partial_success
 		var wg sync.WaitGroup
 		results := make(chan *FetchResult, 5)
-		client := g.Client()
+		cfg := loadFetchConfig(ctx)
 
 		tasks := g.MapStrStr{
-			"audio_transcription_file":    queryRes.Data.Result.AudioTranscriptionFile,
-			"chapter_file":                queryRes.Data.Result.ChapterFile,
-			"information_extraction_file": queryRes.Data.Result.InformationExtractionFile,
-			"summarization_file":          queryRes.Data.Result.SummarizationFile,
-			"translation_file":            queryRes.Data.Result.TranslationFile,
+			KindAudioTranscription:    queryRes.Data.Result.AudioTranscriptionFile,
+			KindChapter:               queryRes.Data.Result.ChapterFile,
+			KindInformationExtraction: queryRes.Data.Result.InformationExtractionFile,
+			KindSummarization:         queryRes.Data.Result.SummarizationFile,
+			KindTranslation:           queryRes.Data.Result.TranslationFile,
 		}
 
 		for key, url := range tasks {
@@ -109,10 +126,13 @@ func Query(ctx context.Context, taskId string, requestId string) (string, error)
 				wg.Add(1)
 				go func(k, u string) {
 					defer wg.Done()
-					results <- &FetchResult{
-						Key:    k,
-						Result: gjson.New(client.GetContent(ctx, url)),
+					res := &FetchResult{Key: k}
+					var body []byte
+					if body, res.Result, res.Err = fetchFile(ctx, cfg, k, u); res.Err == nil && cfg.Archive {
+						res.Archive = archiveFile(ctx, requestId, taskId, k, body)
 					}
+					res.Size = len(body)
+					results <- res
 				}(key, url)
 			}
 		}
@@ -122,16 +142,136 @@ func Query(ctx context.Context, taskId string, requestId string) (string, error)
 			close(results)
 		}()
 
-		updateData := g.Map{}
+		var (
+			updateData  = g.Map{}
+			resultFiles = map[string]ResultFile{}
+			fetchErrors = map[string]*FetchError{}
+			archives    = map[string]*ArchivedFile{}
+			fetched     int
+			duration    int64
+		)
+		files := lark.Files{}
 		for res := range results {
-			updateData[res.Key] = res.Result
+			if res.Err != nil {
+				g.Log().Errorf(ctx, "[%s] 任务 %s 下载结果文件 %s 失败（%d 次）：%s", requestId, taskId, res.Key, res.Err.Attempts, res.Err.Message)
+				fetchErrors[res.Key] = res.Err
+				continue
+			}
+			fetched++
+			resultFiles[res.Key] = ResultFile{Content: res.Result, Size: res.Size}
+			if res.Archive != nil {
+				archives[res.Key] = res.Archive
+			}
+			setFile(&files, res.Key, res.Result)
+		}
+		// 重新查询 partial_success 的任务时，之前已保存的文件不因本次下载失败而丢失
+		if len(fetchErrors) > 0 {
+			kinds := make([]string, 0, len(fetchErrors))
+			for k := range fetchErrors {
+				kinds = append(kinds, k)
+			}
+			stored, err := loadResultFiles(ctx, requestId, version.Version, kinds...)
+			if err != nil {
+				g.Log().Warningf(ctx, "[%s] 任务 %s %v", requestId, taskId, err)
+			}
+			for _, row := range stored {
+				setFile(&files, row.Kind, row.Content)
+				delete(fetchErrors, row.Kind)
+				fetched++
+			}
+		}
+		// 全部下载失败时不更新任务，等待下次轮询重新获取下载链接
+		if fetched == 0 && len(fetchErrors) > 0 {
+			data := g.Map{"fetch_errors": gjson.New(fetchErrors)}
+			if _, err = dao.Transcription.Ctx(ctx).
+				Data(data).
+				Where("task_id = ? and request_id = ?", taskId, requestId).Update(); err != nil {
+				g.Log().Warningf(ctx, "[%s] 任务 %s 记录下载失败原因失败：%v", requestId, taskId, err)
+			}
+			if _, err = dao.TranscriptionVersion.Ctx(ctx).
+				Data(data).
+				Where("task_id = ?", taskId).Update(); err != nil {
+				g.Log().Warningf(ctx, "[%s] 任务 %s 记录下载失败原因失败：%v", requestId, taskId, err)
+			}
+			return "", gerror.Newf("任务 %s 的结果文件全部下载失败", taskId)
+		}
+		status := StatusSuccess
+		updateData["fetch_errors"] = nil
+		if len(fetchErrors) > 0 {
+			status = StatusPartialSuccess
+			updateData["fetch_errors"] = gjson.New(fetchErrors)
+		}
+		if len(archives) > 0 {
+			merged, err := mergeArchives(ctx, taskId, archives)
+			if err != nil {
+				g.Log().Warningf(ctx, "[%s] 任务 %s %v", requestId, taskId, err)
+			}
+			updateData["result_archive"] = gjson.New(merged)
+		}
+
+		// 解析类型化结果。解析失败只记录日志，原始结果文件仍然保存，之后可以重新解析。
+		result, err := lark.Decode(files)
+		if err != nil {
+			g.Log().Errorf(ctx, "[%s] 任务 %s 结果解析失败：%v", requestId, taskId, err)
+		} else {
+			if err = result.Validate(); err != nil {
+				g.Log().Warningf(ctx, "[%s] 任务 %s %v", requestId, taskId, err)
+			}
+			typed := gjson.New(result)
+			resultFiles[KindResult] = ResultFile{Content: typed, Size: len(typed.MustToJson())}
+			if duration = result.Duration(); duration > 0 {
+				updateData["duration"] = keepDuration(duration)
+			}
+			// 重新处理已有修正和说话人映射的任务时，检索文本和对话统计都基于应用后的结果
+			if merged, _, err := overlay(ctx, requestId, result, LatestRevision); err != nil {
+				g.Log().Warningf(ctx, "[%s] 任务 %s 读取转写修正失败：%v", requestId, taskId, err)
+			} else {
+				result = merged
+			}
+			stats := gjson.New(analytics.Compute(result, effectiveDuration(ctx, requestId, result)))
+			resultFiles[KindAnalytics] = ResultFile{Content: stats, Size: len(stats.MustToJson())}
+		}
+		updateData["status"] = status
+		queryRes.Data.Status = status
+		versionData := g.Map{}
+		for k, v := range updateData {
+			versionData[k] = v
+		}
+		if duration > 0 {
+			versionData["duration"] = duration
 		}
-		updateData["status"] = queryRes.Data.Status
-		if _, err = dao.Transcription.Ctx(ctx).
+		if err = saveResultFiles(ctx, requestId, version.Version, resultFiles); err != nil {
+			return "", err
+		}
+		if _, err = dao.TranscriptionVersion.Ctx(ctx).
+			Data(versionData).
+			Where("task_id = ?", taskId).Update(); err != nil {
+			return "", gerror.Wrap(err, "更新数据库失败")
+		}
+		affected, err := dao.Transcription.Ctx(ctx).
 			Data(updateData).
-			Where("task_id = ? and request_id = ?", taskId, requestId).Update(); err != nil {
+			Where("task_id = ? and request_id = ?", taskId, requestId).UpdateAndGetAffected()
+		if err != nil {
 			return "", gerror.Wrap(err, "更新数据库失败")
 		}
+		// 重新处理的版本不是当前版本，按需自动切换，检索文本和待办在切换时重建
+		if affected == 0 {
+			if version.AutoActivate {
+				if err = Activate(ctx, requestId, version.Version); err != nil {
+					g.Log().Errorf(ctx, "[%s] 任务 %s 切换到版本 %d 失败：%v", requestId, taskId, version.Version, err)
+				}
+			}
+			result = nil
+		}
+		// 检索文本和待办失败只记录日志，不影响任务状态
+		if result != nil {
+			if err = IndexText(ctx, requestId, result); err != nil {
+				g.Log().Warningf(ctx, "[%s] 任务 %s 写入检索文本失败：%v", requestId, taskId, err)
+			}
+			if err = todo.Extract(ctx, requestId, result); err != nil {
+				g.Log().Warningf(ctx, "[%s] 任务 %s 抽取待办失败：%v", requestId, taskId, err)
+			}
+		}
 	}
 
 	g.Log().Infof(ctx, "[%s] 任务 %s 查询结果：%s", requestId, taskId, queryRes.Data.Status)
@@ -10,12 +10,20 @@ import (
 )
 
 func Recover(ctx context.Context) {
-	transRecords := []entity.Transcription{}
-	dao.Transcription.Ctx(ctx).WhereIn("status", []string{"submitted", "running"}).Scan(&transRecords)
-	for _, v := range transRecords {
+	// 提交到火山云的过程中服务退出的版本拿不到任务ID，无法继续轮询，标记为失败
+	if _, err := dao.TranscriptionVersion.Ctx(ctx).
+		Data(g.Map{"status": "failed"}).
+		Where("status", StatusSubmitting).
+		Update(); err != nil {
+		g.Log().Errorf(ctx, "标记中断的提交失败：%v", err)
+	}
+	// 首次提交和重新处理都记录在结果版本中
+	versions := []entity.TranscriptionVersion{}
+	dao.TranscriptionVersion.Ctx(ctx).WhereIn("status", []string{"submitted", "running"}).Scan(&versions)
+	for _, v := range versions {
 		Polling(v.TaskId, v.RequestId)
 	}
-	if len(transRecords) > 0 {
-		g.Log().Infof(ctx, "已恢复 pending 状态任务 %d 个", len(transRecords))
+	if len(versions) > 0 {
+		g.Log().Infof(ctx, "已恢复 pending 状态任务 %d 个", len(versions))
 	}
 }
@@ -0,0 +1,104 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+)
+
+// Result 返回任务最新的类型化结果：在上游结果上依次应用转写修正和说话人映射。任务尚未产生任何结果时返回 nil。
+func Result(ctx context.Context, record *entity.Transcription) (*lark.Result, error) {
+	result, _, err := ResultAt(ctx, record, LatestRevision)
+	return result, err
+}
+
+// ResultAt 返回指定修订的类型化结果以及实际使用的修订号。revision 为 0 时只应用说话人映射。
+func ResultAt(ctx context.Context, record *entity.Transcription, revision int) (*lark.Result, int, error) {
+	result, err := upstreamResult(ctx, record)
+	if err != nil || result == nil {
+		return result, 0, err
+	}
+	return overlay(ctx, record.RequestId, result, revision)
+}
+
+// overlay 在上游结果上应用指定修订的转写修正和说话人映射。
+func overlay(ctx context.Context, requestId string, result *lark.Result, revision int) (*lark.Result, int, error) {
+	revision, corrections, err := Corrections(ctx, requestId, revision)
+	if err != nil {
+		return nil, 0, err
+	}
+	profiles, err := SpeakerProfiles(ctx, requestId)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result.WithCorrections(corrections).WithSpeakers(profiles), revision, nil
+}
+
+// upstreamResult 返回当前版本上游原始的类型化结果。优先使用轮询时保存的类型化结果（KindResult），
+// 没有保存（解析失败或旧任务）时从原始结果文件现场解析。任务尚未提交时返回 nil。
+func upstreamResult(ctx context.Context, record *entity.Transcription) (*lark.Result, error) {
+	if record.Version <= 0 {
+		return nil, nil
+	}
+	rows, err := loadResultFiles(ctx, record.RequestId, record.Version, KindResult)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 {
+		var result *lark.Result
+		if err = rows[0].Content.Scan(&result); err != nil {
+			return nil, gerror.Wrap(err, "解析任务结果失败")
+		}
+		return result, nil
+	}
+
+	files, err := ResultFiles(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if files == (lark.Files{}) {
+		return nil, nil
+	}
+	result, err := lark.Decode(files)
+	if err != nil {
+		return nil, err
+	}
+	if err = result.Validate(); err != nil {
+		g.Log().Warningf(ctx, "[%s] %v", record.RequestId, err)
+	}
+	return result, nil
+}
+
+// GetRecord 读取任务记录。任务不存在时返回 CodeNotFound 错误。
+func GetRecord(ctx context.Context, requestId string) (*entity.Transcription, error) {
+	var record *entity.Transcription
+	if err := dao.Transcription.Ctx(ctx).
+		Where(dao.Transcription.Columns().RequestId+" = ?", requestId).
+		Limit(1).
+		Scan(&record); err != nil {
+		return nil, gerror.Wrap(err, "获取任务记录失败")
+	}
+	if record == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "任务不存在")
+	}
+	return record, nil
+}
+
+// LoadResult 读取任务记录及其类型化结果。任务不存在时返回 CodeNotFound 错误。
+func LoadResult(ctx context.Context, requestId string) (*entity.Transcription, *lark.Result, error) {
+	record, err := GetRecord(ctx, requestId)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := Result(ctx, record)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, result, nil
+}
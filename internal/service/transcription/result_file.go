@@ -0,0 +1,122 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+)
+
+// 结果文件的种类，与火山云查询结果中的字段以及 fetch_errors、result_archive 的键一致
+const (
+	KindAudioTranscription    = "audio_transcription_file"
+	KindChapter               = "chapter_file"
+	KindInformationExtraction = "information_extraction_file"
+	KindSummarization         = "summarization_file"
+	KindTranslation           = "translation_file"
+)
+
+// KindResult 是由原始结果文件解析得到的类型化结果（lark.Result），与原始结果文件存放在同一张表中，按需读取
+const KindResult = "result"
+
+// KindAnalytics 是对话统计（analytics.Analytics）的缓存，已应用转写修正和说话人映射，修正或映射变化后删除
+const KindAnalytics = "analytics"
+
+// fileKinds 是全部原始结果文件的种类
+var fileKinds = []string{KindAudioTranscription, KindChapter, KindInformationExtraction, KindSummarization, KindTranslation}
+
+// ResultFile 是下载到的一个原始结果文件。
+type ResultFile struct {
+	Content *gjson.Json
+	Size    int // 原始字节数
+}
+
+// saveResultFiles 保存一个结果版本的原始结果文件，同一种类的文件覆盖之前的内容。
+func saveResultFiles(ctx context.Context, requestId string, version int, files map[string]ResultFile) error {
+	cols := dao.TranscriptionResult.Columns()
+	now := gtime.Now()
+	for kind, f := range files {
+		if _, err := dao.TranscriptionResult.Ctx(ctx).
+			Data(g.Map{
+				cols.RequestId: requestId,
+				cols.Version:   version,
+				cols.Kind:      kind,
+				cols.Content:   f.Content,
+				cols.Size:      f.Size,
+				cols.FetchedAt: now,
+			}).
+			OnConflict(cols.RequestId, cols.Version, cols.Kind).
+			Save(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "保存结果文件失败")
+		}
+	}
+	return nil
+}
+
+// ResultFiles 读取任务当前版本的原始结果文件。kinds 为空时读取全部种类；任务尚未提交时返回空结果。
+func ResultFiles(ctx context.Context, record *entity.Transcription, kinds ...string) (lark.Files, error) {
+	var files lark.Files
+	if record.Version <= 0 {
+		return files, nil
+	}
+	rows, err := loadResultFiles(ctx, record.RequestId, record.Version, kinds...)
+	if err != nil {
+		return files, err
+	}
+	for _, row := range rows {
+		setFile(&files, row.Kind, row.Content)
+	}
+	return files, nil
+}
+
+// loadResultFiles 读取一个结果版本已保存的原始结果文件。kinds 为空时读取全部原始结果文件，不含类型化结果和对话统计。
+func loadResultFiles(ctx context.Context, requestId string, version int, kinds ...string) ([]*entity.TranscriptionResult, error) {
+	cols := dao.TranscriptionResult.Columns()
+	m := dao.TranscriptionResult.Ctx(ctx).
+		Fields(cols.Kind, cols.Content).
+		Where(cols.RequestId+" = ?", requestId).
+		Where(cols.Version+" = ?", version)
+	if len(kinds) == 0 {
+		kinds = fileKinds
+	}
+	m = m.WhereIn(cols.Kind, kinds)
+	var rows []*entity.TranscriptionResult
+	if err := m.Scan(&rows); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "读取结果文件失败")
+	}
+	return rows, nil
+}
+
+// setFile 按种类把结果文件放入 files。
+func setFile(files *lark.Files, kind string, content *gjson.Json) {
+	switch kind {
+	case KindAudioTranscription:
+		files.AudioTranscription = content
+	case KindChapter:
+		files.Chapter = content
+	case KindInformationExtraction:
+		files.InformationExtraction = content
+	case KindSummarization:
+		files.Summarization = content
+	case KindTranslation:
+		files.Translation = content
+	}
+}
+
+// MetaFields 返回任务元数据（v1.TaskMeta）对应的字段，列表接口只读取这些字段，不读取结果内容。
+// 另外包含计算 ETag 所需的 updated_at 和修改计数。
+func MetaFields() []any {
+	cols := dao.Transcription.Columns()
+	return []any{
+		cols.Id, cols.RequestId, cols.Owner, cols.WorkspaceId, cols.Title, cols.Description, cols.Tags, cols.Folder,
+		cols.Duration, cols.FileInfo, cols.Status, cols.FetchErrors, cols.TaskParams, cols.CreatedAt,
+		cols.UpdatedAt, cols.ChangeSeq,
+	}
+}
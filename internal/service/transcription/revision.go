@@ -0,0 +1,270 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+)
+
+// LatestRevision 表示读取最新修订。修订 0 表示上游原始结果。
+const LatestRevision = -1
+
+// Corrections 读取某个修订的全部修正。revision 为 LatestRevision 时读取最新修订。
+// 返回实际读取的修订号，没有任何修订时为 0。
+func Corrections(ctx context.Context, requestId string, revision int) (int, []lark.Correction, error) {
+	if revision == 0 {
+		return 0, nil, nil
+	}
+	cols := dao.TranscriptionRevision.Columns()
+	m := dao.TranscriptionRevision.Ctx(ctx).Where(cols.RequestId+" = ?", requestId)
+	if revision > 0 {
+		m = m.Where(cols.Revision+" = ?", revision)
+	} else {
+		m = m.OrderDesc(cols.Revision)
+	}
+	var record *entity.TranscriptionRevision
+	if err := m.Limit(1).Scan(&record); err != nil {
+		return 0, nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询修订失败")
+	}
+	if record == nil {
+		if revision > 0 {
+			return 0, nil, gerror.NewCodef(gcode.CodeNotFound, "修订不存在：%d", revision)
+		}
+		return 0, nil, nil
+	}
+	var corrections []lark.Correction
+	if err := record.Corrections.Scan(&corrections); err != nil {
+		return 0, nil, gerror.Wrap(err, "解析修订内容失败")
+	}
+	return record.Revision, corrections, nil
+}
+
+// Revisions 按修订号倒序列出任务的全部修订。
+func Revisions(ctx context.Context, requestId string) ([]entity.TranscriptionRevision, error) {
+	var records []entity.TranscriptionRevision
+	cols := dao.TranscriptionRevision.Columns()
+	if err := dao.TranscriptionRevision.Ctx(ctx).
+		Where(cols.RequestId+" = ?", requestId).
+		OrderDesc(cols.Revision).
+		Scan(&records); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询修订失败")
+	}
+	return records, nil
+}
+
+// SaveCorrections 把 patch 合并到最新修订上，生成一个新修订并返回新修订号。
+// patch 中没有修改任何字段的条目表示撤销该句的修正。当前版本中不存在的句子的修正原样保留。baseRevision 必须等于当前最新修订，防止覆盖他人的修改。
+func SaveCorrections(ctx context.Context, record *entity.Transcription, author string, baseRevision int, patch []lark.Correction, comment string) (int, error) {
+	latest, current, err := Corrections(ctx, record.RequestId, LatestRevision)
+	if err != nil {
+		return 0, err
+	}
+	if baseRevision != latest {
+		return 0, gerror.NewCodef(gcode.CodeInvalidOperation, "转写已被修改，当前最新修订为 %d，请刷新后重试", latest)
+	}
+
+	result, err := upstreamResult(ctx, record)
+	if err != nil {
+		return 0, err
+	}
+	if result == nil {
+		return 0, gerror.NewCode(gcode.CodeInvalidOperation, "任务尚未完成，无法修改转写")
+	}
+	known := sentenceIds(result)
+	bySentence := make(map[string]lark.Correction, len(current))
+	for _, c := range current {
+		bySentence[c.SentenceId] = c
+	}
+	// 切换结果版本后，之前版本的句子可能已不存在。这些句子已有的修正原样保留，切换回去时仍然生效；
+	// 新的修改只能针对当前版本中的句子，撤销修正不受限制
+	for _, p := range patch {
+		if p.IsEmpty() {
+			delete(bySentence, p.SentenceId)
+			continue
+		}
+		if _, ok := known[p.SentenceId]; !ok {
+			return 0, gerror.NewCodef(gcode.CodeInvalidParameter, "修正内容无效：句子不存在：%s", p.SentenceId)
+		}
+		c := bySentence[p.SentenceId].Merge(p)
+		c.SentenceId = p.SentenceId
+		bySentence[p.SentenceId] = c
+	}
+	merged := make([]lark.Correction, 0, len(bySentence))
+	for _, c := range bySentence {
+		merged = append(merged, c)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].SentenceId < merged[j].SentenceId })
+
+	return saveRevision(ctx, record, author, latest, merged, comment)
+}
+
+// RevertRevision 以某个历史修订的内容生成一个新修订，历史记录保持线性。revision 为 0 时恢复为上游原始结果。
+func RevertRevision(ctx context.Context, record *entity.Transcription, author string, revision int) (int, error) {
+	latest, _, err := Corrections(ctx, record.RequestId, LatestRevision)
+	if err != nil {
+		return 0, err
+	}
+	if revision >= latest {
+		return 0, gerror.NewCode(gcode.CodeInvalidParameter, "只能恢复到更早的修订")
+	}
+	_, corrections, err := Corrections(ctx, record.RequestId, revision)
+	if err != nil {
+		return 0, err
+	}
+	return saveRevision(ctx, record, author, latest, corrections, fmt.Sprintf("恢复到修订 %d", revision))
+}
+
+func saveRevision(ctx context.Context, record *entity.Transcription, author string, latest int, corrections []lark.Correction, comment string) (int, error) {
+	result, err := upstreamResult(ctx, record)
+	if err != nil {
+		return 0, err
+	}
+	if result == nil {
+		return 0, gerror.NewCode(gcode.CodeInvalidOperation, "任务尚未完成，无法修改转写")
+	}
+	// 只校验当前版本中存在的句子的修正，其余句子的修正在应用时被忽略
+	present := make([]lark.Correction, 0, len(corrections))
+	known := sentenceIds(result)
+	for _, c := range corrections {
+		if _, ok := known[c.SentenceId]; ok {
+			present = append(present, c)
+		}
+	}
+	if err = result.ValidateCorrections(present); err != nil {
+		return 0, gerror.WrapCode(gcode.CodeInvalidParameter, err, "修正内容无效")
+	}
+	if corrections == nil {
+		corrections = []lark.Correction{}
+	}
+
+	next := latest + 1
+	cols := dao.TranscriptionRevision.Columns()
+	tcols := dao.Transcription.Columns()
+	err = dao.TranscriptionRevision.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		// 锁住任务记录，同一任务的修订串行保存。PostgreSQL 不允许在聚合查询上加 FOR UPDATE
+		if _, err := dao.Transcription.Ctx(ctx).
+			Fields(tcols.Id).
+			Where(tcols.RequestId+" = ?", record.RequestId).
+			LockUpdate().
+			One(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询任务记录失败")
+		}
+		count, err := dao.TranscriptionRevision.Ctx(ctx).
+			Where(cols.RequestId+" = ?", record.RequestId).
+			Where(cols.Revision+" >= ?", next).
+			Count()
+		if err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询修订失败")
+		}
+		if count > 0 {
+			return gerror.NewCode(gcode.CodeInvalidOperation, "转写已被修改，请刷新后重试")
+		}
+		if _, err = dao.TranscriptionRevision.Ctx(ctx).Data(g.Map{
+			cols.RequestId:   record.RequestId,
+			cols.Revision:    next,
+			cols.Corrections: gjson.New(corrections),
+			cols.Comment:     comment,
+			cols.Author:      author,
+		}).Insert(); err != nil {
+			// 并发保存时唯一约束冲突
+			return gerror.WrapCode(gcode.CodeInvalidOperation, err, "保存修订失败，可能已被他人修改，请刷新后重试")
+		}
+		if err := invalidateAnalytics(ctx, record.RequestId); err != nil {
+			return err
+		}
+		return touch(ctx, record.RequestId)
+	})
+	if err != nil {
+		return 0, err
+	}
+	reindexText(ctx, record)
+	return next, nil
+}
+
+// sentenceIds 返回结果中全部句子的ID。
+func sentenceIds(result *lark.Result) map[string]struct{} {
+	ids := make(map[string]struct{}, len(result.Utterances))
+	for _, u := range result.Utterances {
+		ids[u.SentenceId] = struct{}{}
+	}
+	return ids
+}
+
+// ApplyCorrectionsToRaw 把修正应用到 v1 接口返回的原始转写文件上，单词时间序列的处理和句子的排序与 lark.Result.WithCorrections 相同。
+// 返回的是副本，数据库中保存的上游原始结果不变。
+func ApplyCorrectionsToRaw(raw *gjson.Json, corrections []lark.Correction) *gjson.Json {
+	if raw == nil || raw.IsNil() || len(corrections) == 0 {
+		return raw
+	}
+	if _, ok := raw.Interface().([]any); !ok {
+		return raw
+	}
+	bySentence := make(map[string]lark.Correction, len(corrections))
+	for _, c := range corrections {
+		bySentence[c.SentenceId] = c
+	}
+
+	out := gjson.New(raw.MustToJson())
+	items := out.Interface().([]any)
+	names := upstreamNames(items)
+	var retimed bool
+	for _, item := range items {
+		m, _ := item.(map[string]any)
+		c, ok := bySentence[gconv.String(m["sentence_id"])]
+		if !ok {
+			continue
+		}
+		start, end := gconv.Int64(m["start_time"]), gconv.Int64(m["end_time"])
+		if c.Content != nil && *c.Content != gconv.String(m["content"]) {
+			m["content"] = *c.Content
+			m["words"] = nil
+		}
+		if c.ChangesSpeaker() {
+			speaker := rawSpeaker(m)
+			if speaker == nil {
+				speaker = map[string]any{}
+				m["speaker"] = speaker
+			}
+			speaker["id"], speaker["name"] = *c.SpeakerId, names[*c.SpeakerId]
+		}
+		if c.StartTime != nil {
+			m["start_time"] = *c.StartTime
+		}
+		if c.EndTime != nil {
+			m["end_time"] = *c.EndTime
+		}
+		newStart, newEnd := gconv.Int64(m["start_time"]), gconv.Int64(m["end_time"])
+		retimed = retimed || newStart != start
+		if words, ok := m["words"].([]any); ok && (newStart != start || newEnd != end) {
+			for _, w := range words {
+				if wm, ok := w.(map[string]any); ok {
+					wm["start_time"] = lark.RescaleTime(gconv.Int64(wm["start_time"]), start, end, newStart, newEnd)
+					wm["end_time"] = lark.RescaleTime(gconv.Int64(wm["end_time"]), start, end, newStart, newEnd)
+				}
+			}
+		}
+	}
+	// 原地排序，out 中的数组随之改变
+	if retimed {
+		sort.SliceStable(items, func(i, j int) bool {
+			return rawStartTime(items[i]) < rawStartTime(items[j])
+		})
+	}
+	return out
+}
+
+func rawStartTime(item any) int64 {
+	m, _ := item.(map[string]any)
+	return gconv.Int64(m["start_time"])
+}
@@ -0,0 +1,209 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+)
+
+// SpeakerProfiles 读取任务的说话人映射。
+func SpeakerProfiles(ctx context.Context, requestId string) ([]lark.SpeakerProfile, error) {
+	var records []entity.TranscriptionSpeaker
+	cols := dao.TranscriptionSpeaker.Columns()
+	if err := dao.TranscriptionSpeaker.Ctx(ctx).
+		Where(cols.RequestId+" = ?", requestId).
+		Scan(&records); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询说话人映射失败")
+	}
+	profiles := make([]lark.SpeakerProfile, 0, len(records))
+	for _, r := range records {
+		profiles = append(profiles, lark.SpeakerProfile{
+			SpeakerId: r.SpeakerId,
+			Name:      r.Name,
+			Profile:   r.Profile,
+			MergeInto: r.MergeInto,
+		})
+	}
+	return profiles, nil
+}
+
+// ApplySpeakersToRaw 把说话人映射应用到 v1 接口返回的原始转写 / 翻译文件上。
+// 返回的是副本，数据库中保存的上游原始结果不变。
+func ApplySpeakersToRaw(raw *gjson.Json, profiles []lark.SpeakerProfile) *gjson.Json {
+	if raw == nil || raw.IsNil() || len(profiles) == 0 {
+		return raw
+	}
+	byId := make(map[string]lark.SpeakerProfile, len(profiles))
+	for _, p := range profiles {
+		byId[p.SpeakerId] = p
+	}
+	out := gjson.New(raw.MustToJson())
+	items, ok := out.Interface().([]any)
+	if !ok {
+		items = []any{out.Interface()}
+	}
+	names := upstreamNames(items)
+	for _, item := range items {
+		speaker := rawSpeaker(item)
+		id := gconv.String(speaker["id"])
+		if id == "" {
+			continue
+		}
+		resolved := lark.ResolveSpeaker(byId, id)
+		if resolved != id {
+			speaker["id"] = resolved
+		}
+		if p, ok := byId[resolved]; ok && p.Name != "" {
+			speaker["name"] = p.Name
+		} else if resolved != id {
+			speaker["name"] = names[resolved]
+		}
+	}
+	return out
+}
+
+// rawSpeaker 返回原始结果中一句的 speaker 对象，没有时为 nil。修改返回的 map 会直接修改该句。
+func rawSpeaker(item any) map[string]any {
+	m, _ := item.(map[string]any)
+	speaker, _ := m["speaker"].(map[string]any)
+	return speaker
+}
+
+// upstreamNames 返回原始结果中每个说话人的上游名称，用于合并后没有改名的目标说话人。
+// 必须在修改说话人之前调用。
+func upstreamNames(items []any) map[string]string {
+	names := make(map[string]string)
+	for _, item := range items {
+		speaker := rawSpeaker(item)
+		id := gconv.String(speaker["id"])
+		if _, ok := names[id]; id != "" && !ok {
+			names[id] = gconv.String(speaker["name"])
+		}
+	}
+	return names
+}
+
+// SpeakerInfo 是任务中一个上游说话人及其映射。
+type SpeakerInfo struct {
+	Id           string
+	OriginalName string
+	Name         string
+	Profile      string
+	MergeInto    string
+	Utterances   int
+}
+
+// Speakers 列出上游结果中的说话人及当前映射。
+func Speakers(ctx context.Context, record *entity.Transcription) ([]SpeakerInfo, error) {
+	result, err := upstreamResult(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return []SpeakerInfo{}, nil
+	}
+	profiles, err := SpeakerProfiles(ctx, record.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[string]lark.SpeakerProfile, len(profiles))
+	for _, p := range profiles {
+		byId[p.SpeakerId] = p
+	}
+	counts := map[string]int{}
+	for _, u := range result.Utterances {
+		counts[u.SpeakerId]++
+	}
+	infos := make([]SpeakerInfo, 0, len(result.Speakers))
+	for _, s := range result.Speakers {
+		p := byId[s.Id]
+		infos = append(infos, SpeakerInfo{
+			Id:           s.Id,
+			OriginalName: s.Name,
+			Name:         p.Name,
+			Profile:      p.Profile,
+			MergeInto:    p.MergeInto,
+			Utterances:   counts[s.Id],
+		})
+	}
+	return infos, nil
+}
+
+// SetSpeakers 校验并整体替换任务的说话人映射。
+func SetSpeakers(ctx context.Context, record *entity.Transcription, user string, profiles []lark.SpeakerProfile) error {
+	result, err := upstreamResult(ctx, record)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return gerror.NewCode(gcode.CodeInvalidOperation, "任务尚未完成，没有可映射的说话人")
+	}
+
+	byId := make(map[string]lark.SpeakerProfile, len(profiles))
+	for _, p := range profiles {
+		if _, ok := result.Speaker(p.SpeakerId); !ok {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, "说话人不存在：%s", p.SpeakerId)
+		}
+		if _, ok := byId[p.SpeakerId]; ok {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, "说话人重复：%s", p.SpeakerId)
+		}
+		byId[p.SpeakerId] = p
+	}
+	for _, p := range profiles {
+		if p.MergeInto == "" {
+			continue
+		}
+		if p.MergeInto == p.SpeakerId {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, "说话人 %s 不能合并到自己", p.SpeakerId)
+		}
+		if _, ok := result.Speaker(p.MergeInto); !ok {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, "合并目标说话人不存在：%s", p.MergeInto)
+		}
+		if byId[p.MergeInto].MergeInto != "" {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, "合并目标说话人 %s 本身已被合并", p.MergeInto)
+		}
+	}
+
+	cols := dao.TranscriptionSpeaker.Columns()
+	return dao.TranscriptionSpeaker.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.TranscriptionSpeaker.Ctx(ctx).
+			Where(cols.RequestId+" = ?", record.RequestId).
+			Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "清除说话人映射失败")
+		}
+		// 对话统计中包含说话人名称，合并说话人也会改变统计
+		if err := invalidateAnalytics(ctx, record.RequestId); err != nil {
+			return err
+		}
+		if err := touch(ctx, record.RequestId); err != nil {
+			return err
+		}
+		if len(profiles) == 0 {
+			return nil
+		}
+		data := make(g.List, 0, len(profiles))
+		for _, p := range profiles {
+			data = append(data, g.Map{
+				cols.RequestId: record.RequestId,
+				cols.SpeakerId: p.SpeakerId,
+				cols.Name:      p.Name,
+				cols.Profile:   p.Profile,
+				cols.MergeInto: p.MergeInto,
+				cols.UpdatedBy: user,
+			})
+		}
+		if _, err := dao.TranscriptionSpeaker.Ctx(ctx).Data(data).Insert(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "保存说话人映射失败")
+		}
+		return nil
+	})
+}
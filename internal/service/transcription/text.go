@@ -0,0 +1,219 @@
+package transcription
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+)
+
+// 全文检索片段的类型
+const (
+	TextKindSummary   = "summary"
+	TextKindChapter   = "chapter"
+	TextKindTodo      = "todo"
+	TextKindUtterance = "utterance"
+)
+
+// 各类片段的 seq 起点，保证同一任务内按 全文总结、章节、待办、转写 的顺序排列。需与 migrations/11.sql 保持一致
+const (
+	seqChapter   = 100000
+	seqTodo      = 200000
+	seqUtterance = 300000
+)
+
+// snippetContext 是高亮片段中关键词前后保留的字符数
+const snippetContext = 30
+
+// TextMatch 是一个命中关键词的文本片段。
+type TextMatch struct {
+	Kind       string // 片段类型
+	SentenceId string // 转写句子ID，其他类型为空
+	Snippet    string // 已做 HTML 转义的片段，关键词用 <mark> 包裹
+	StartTime  int64  // 开始时间（毫秒），待办和全文总结为 0
+	EndTime    int64  // 结束时间（毫秒）
+}
+
+// IndexText 重建任务的全文检索片段。result 为 nil 时只清空。
+func IndexText(ctx context.Context, requestId string, result *lark.Result) error {
+	rows := textRows(requestId, result)
+	cols := dao.TranscriptionText.Columns()
+	return dao.TranscriptionText.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.TranscriptionText.Ctx(ctx).Where(cols.RequestId+" = ?", requestId).Delete(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "清理检索文本失败")
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if _, err := dao.TranscriptionText.Ctx(ctx).Data(rows).Batch(500).Insert(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "写入检索文本失败")
+		}
+		return nil
+	})
+}
+
+// reindexText 用任务最新的结果（含转写修正）重建检索片段。失败只记录日志，不影响调用方。
+func reindexText(ctx context.Context, record *entity.Transcription) {
+	result, err := Result(ctx, record)
+	if err == nil {
+		err = IndexText(ctx, record.RequestId, result)
+	}
+	if err != nil {
+		g.Log().Warningf(ctx, "[%s] 重建检索文本失败：%v", record.RequestId, err)
+	}
+}
+
+func textRows(requestId string, result *lark.Result) g.List {
+	if result == nil {
+		return nil
+	}
+	var rows g.List
+	add := func(kind string, seq int, ref, content string, start, end int64) {
+		if content = strings.TrimSpace(content); content == "" {
+			return
+		}
+		cols := dao.TranscriptionText.Columns()
+		rows = append(rows, g.Map{
+			cols.RequestId: requestId,
+			cols.Kind:      kind,
+			cols.Seq:       seq,
+			cols.Ref:       ref,
+			cols.Content:   content,
+			cols.StartTime: start,
+			cols.EndTime:   end,
+		})
+	}
+	if result.Summary != nil {
+		add(TextKindSummary, 0, "", result.Summary.Title, 0, 0)
+		add(TextKindSummary, 1, "", result.Summary.Paragraph, 0, 0)
+	}
+	for i, c := range result.Chapters {
+		content := c.Summary
+		if c.Title != "" && c.Summary != "" {
+			content = c.Title + "：" + c.Summary
+		} else if c.Title != "" {
+			content = c.Title
+		}
+		add(TextKindChapter, seqChapter+i+1, "", content, c.StartTime, c.EndTime)
+	}
+	for i, t := range result.Todos {
+		add(TextKindTodo, seqTodo+i+1, "", t.Content, 0, 0)
+	}
+	for i, u := range result.Utterances {
+		add(TextKindUtterance, seqUtterance+i+1, u.SentenceId, u.Content, u.StartTime, u.EndTime)
+	}
+	return rows
+}
+
+// likeEscaper 转义 LIKE 模式中的特殊字符
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsPattern 返回匹配包含 keyword 的 ILIKE 模式，keyword 中的 %、_ 和 \ 按字面匹配。
+// 使用该模式的条件需要带上 ESCAPE '\'。
+func ContainsPattern(keyword string) string {
+	return "%" + likeEscaper.Replace(keyword) + "%"
+}
+
+// TextMatchCondition 返回匹配全文检索片段的任务条件，参数为 ContainsPattern 返回的模式。
+func TextMatchCondition(requestIdColumn string) string {
+	cols := dao.TranscriptionText.Columns()
+	return requestIdColumn + " IN (SELECT " + cols.RequestId + " FROM " + dao.TranscriptionText.Table() + " WHERE " + cols.Content + ` ILIKE ? ESCAPE '\')`
+}
+
+// SearchText 在指定任务中查找包含关键词的片段，每个任务最多返回 perTask 条，按任务内顺序排列。
+func SearchText(ctx context.Context, requestIds []string, keyword string, perTask int) (map[string][]TextMatch, error) {
+	out := make(map[string][]TextMatch, len(requestIds))
+	if len(requestIds) == 0 || keyword == "" {
+		return out, nil
+	}
+	cols := dao.TranscriptionText.Columns()
+	var rows []entity.TranscriptionText
+	if err := dao.TranscriptionText.DB().Raw(
+		"SELECT * FROM (SELECT *, ROW_NUMBER() OVER (PARTITION BY "+cols.RequestId+" ORDER BY "+cols.Seq+") AS rn FROM "+
+			dao.TranscriptionText.Table()+" WHERE "+cols.RequestId+" IN (?) AND "+cols.Content+` ILIKE ? ESCAPE '\') t WHERE rn <= ? ORDER BY `+
+			cols.RequestId+", "+cols.Seq,
+		requestIds, ContainsPattern(keyword), perTask,
+	).Ctx(ctx).Scan(&rows); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询检索文本失败")
+	}
+	for _, r := range rows {
+		out[r.RequestId] = append(out[r.RequestId], TextMatch{
+			Kind:       r.Kind,
+			SentenceId: r.Ref,
+			Snippet:    Highlight(r.Content, keyword, snippetContext),
+			StartTime:  r.StartTime,
+			EndTime:    r.EndTime,
+		})
+	}
+	return out, nil
+}
+
+// Highlight 截取 content 中第一次出现 keyword（不区分大小写）前后各 around 个字符，
+// 做 HTML 转义后用 <mark> 包裹所有命中的关键词。
+func Highlight(content, keyword string, around int) string {
+	text, key := []rune(content), []rune(keyword)
+	lower, lowerKey := foldRunes(text), foldRunes(key)
+	first := indexRunes(lower, lowerKey, 0)
+	if first < 0 || len(key) == 0 {
+		if len(text) > 2*around {
+			return html.EscapeString(string(text[:2*around])) + "…"
+		}
+		return html.EscapeString(content)
+	}
+
+	start, end := max(0, first-around), min(len(text), first+len(key)+around)
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		j := indexRunes(lower[:end], lowerKey, i)
+		if j < 0 {
+			b.WriteString(html.EscapeString(string(text[i:end])))
+			break
+		}
+		b.WriteString(html.EscapeString(string(text[i:j])))
+		b.WriteString("<mark>" + html.EscapeString(string(text[j:j+len(key)])) + "</mark>")
+		i = j + len(key)
+	}
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func foldRunes(runes []rune) []rune {
+	out := make([]rune, len(runes))
+	for i, r := range runes {
+		out[i] = unicode.ToLower(r)
+	}
+	return out
+}
+
+func indexRunes(text, key []rune, from int) int {
+	if len(key) == 0 {
+		return -1
+	}
+	for i := from; i+len(key) <= len(text); i++ {
+		match := true
+		for k := range key {
+			if text[i+k] != key[k] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,335 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/text/gstr"
+
+	v1 "doubao-speech-service/api/transcription/v1"
+	"doubao-speech-service/internal/consts"
+	"doubao-speech-service/internal/dao"
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/model/lark"
+	"doubao-speech-service/internal/service/todo"
+	"doubao-speech-service/internal/service/volcengine"
+)
+
+// SubmitRequest 是提交给火山云的任务请求，同时作为 task_params 保存。
+type SubmitRequest struct {
+	Input struct {
+		Offline struct {
+			FileURL  string `json:"FileURL"`
+			FileType string `json:"FileType"`
+		} `json:"Offline"`
+	} `json:"Input"`
+	Params v1.TaskSubmitParams `json:"Params"`
+}
+
+// StatusSubmitting 是结果版本已预留、尚未得到火山云任务ID时的状态
+const StatusSubmitting = "submitting"
+
+// pendingStatuses 是处理中的结果版本的状态，同一任务同一时间只能有一个处理中的版本
+var pendingStatuses = []string{StatusSubmitting, "submitted", "running"}
+
+// Submit 用新的参数为任务提交一次火山云处理，记为一个新的结果版本并开始轮询。
+// 任务还没有当前版本时新版本直接成为当前版本；之后的版本（重新处理）在完成前不影响任务当前的结果，
+// autoActivate 为 true 时处理成功后自动设为当前版本。
+//
+// 提交前先锁住任务记录并预留版本行（状态 submitting），并发的提交只有一个能成功；
+// 火山云提交失败时版本标记为 failed。
+func Submit(ctx context.Context, record *entity.Transcription, params v1.TaskSubmitParams, author string, autoActivate bool) (*entity.TranscriptionVersion, error) {
+	var submitReq SubmitRequest
+	if record.TaskParams != nil && !record.TaskParams.IsNil() {
+		if err := record.TaskParams.Scan(&submitReq); err != nil {
+			return nil, gerror.Wrap(err, "解析数据库任务参数失败")
+		}
+	}
+	fileURL, err := volcengine.GetFileURL(ctx, record)
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取文件URL失败")
+	}
+	submitReq.Input.Offline.FileURL = fileURL
+	submitReq.Params = params
+
+	row, current, err := reserveVersion(ctx, record.RequestId, author, autoActivate)
+	if err != nil {
+		return nil, err
+	}
+	cols := dao.TranscriptionVersion.Columns()
+	// 火山云的请求ID不能重复，重新处理时在任务的请求ID后加上版本号
+	larkRequestId := record.RequestId
+	if row.Version > 1 {
+		larkRequestId = fmt.Sprintf("%s-v%d", record.RequestId, row.Version)
+	}
+	taskId, err := submit(ctx, larkRequestId, submitReq)
+	if err != nil {
+		if _, uerr := dao.TranscriptionVersion.Ctx(ctx).
+			Data(g.Map{cols.Status: "failed", cols.TaskParams: gjson.New(submitReq)}).
+			Where(cols.RequestId+" = ?", row.RequestId).
+			Where(cols.Version+" = ?", row.Version).
+			Update(); uerr != nil {
+			g.Log().Errorf(ctx, "[%s] 版本 %d 标记为失败时出错：%v", record.RequestId, row.Version, uerr)
+		}
+		return nil, err
+	}
+
+	row.TaskId = taskId
+	row.TaskParams = gjson.New(submitReq)
+	row.Status = "submitted"
+	err = dao.TranscriptionVersion.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.TranscriptionVersion.Ctx(ctx).Data(g.Map{
+			cols.TaskId:     row.TaskId,
+			cols.TaskParams: row.TaskParams,
+			cols.Status:     row.Status,
+		}).
+			Where(cols.RequestId+" = ?", row.RequestId).
+			Where(cols.Version+" = ?", row.Version).
+			Update(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "保存结果版本失败")
+		}
+		if !current {
+			return nil
+		}
+		tcols := dao.Transcription.Columns()
+		if _, err := dao.Transcription.Ctx(ctx).Data(g.Map{
+			tcols.Version:    row.Version,
+			tcols.TaskId:     taskId,
+			tcols.TaskParams: submitReq,
+			tcols.Status:     row.Status,
+		}).Where(tcols.RequestId+" = ?", record.RequestId).Update(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "更新任务记录失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	Polling(taskId, record.RequestId)
+	return row, nil
+}
+
+// reserveVersion 在锁住任务记录的事务中检查没有处理中的版本，并插入状态为 submitting 的新版本。
+// 火山云任务ID在提交成功后才知道，预留时 task_id 暂存为版本的唯一标识。current 表示新版本是否直接成为当前版本。
+func reserveVersion(ctx context.Context, requestId, author string, autoActivate bool) (row *entity.TranscriptionVersion, current bool, err error) {
+	cols := dao.TranscriptionVersion.Columns()
+	tcols := dao.Transcription.Columns()
+	err = dao.TranscriptionVersion.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		task, err := dao.Transcription.Ctx(ctx).
+			Fields(tcols.Version).
+			Where(tcols.RequestId+" = ?", requestId).
+			LockUpdate().
+			One()
+		if err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询任务记录失败")
+		}
+		if task.IsEmpty() {
+			return gerror.NewCode(gcode.CodeNotFound, "任务记录不存在")
+		}
+		current = task[tcols.Version].Int() == 0
+		pending, err := dao.TranscriptionVersion.Ctx(ctx).
+			Where(cols.RequestId+" = ?", requestId).
+			WhereIn(cols.Status, pendingStatuses).
+			Count()
+		if err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询结果版本失败")
+		}
+		if pending > 0 {
+			return gerror.NewCode(gcode.CodeInvalidOperation, "已有一次处理在进行中，请等待完成后再试")
+		}
+		latest, err := dao.TranscriptionVersion.Ctx(ctx).
+			Where(cols.RequestId+" = ?", requestId).
+			Max(cols.Version)
+		if err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询结果版本失败")
+		}
+		row = &entity.TranscriptionVersion{
+			RequestId:    requestId,
+			Version:      int(latest) + 1,
+			Status:       StatusSubmitting,
+			AutoActivate: autoActivate,
+			CreatedBy:    author,
+		}
+		row.TaskId = fmt.Sprintf("%s#%d", requestId, row.Version)
+		if _, err = dao.TranscriptionVersion.Ctx(ctx).Data(g.Map{
+			cols.RequestId:    row.RequestId,
+			cols.Version:      row.Version,
+			cols.TaskId:       row.TaskId,
+			cols.Status:       row.Status,
+			cols.AutoActivate: row.AutoActivate,
+			cols.CreatedBy:    row.CreatedBy,
+		}).Insert(); err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "保存结果版本失败")
+		}
+		return nil
+	})
+	return row, current, err
+}
+
+// submit 把任务提交到火山云，返回火山云的任务ID。
+func submit(ctx context.Context, larkRequestId string, submitReq SubmitRequest) (string, error) {
+	response, err := g.Client().ContentJson().
+		SetHeaderMap(g.MapStrStr{
+			"X-Api-App-Key":     g.Cfg().MustGet(ctx, "volc.lark.appid").String(),
+			"X-Api-Access-Key":  g.Cfg().MustGet(ctx, "volc.lark.accessKey").String(),
+			"X-Api-Resource-Id": g.Cfg().MustGet(ctx, "volc.lark.service").String(),
+			"X-Api-Request-Id":  larkRequestId,
+			"X-Api-Sequence":    "-1",
+		}).
+		Post(
+			ctx,
+			"https://openspeech.bytedance.com/api/v3/auc/lark/submit",
+			submitReq,
+		)
+	if err != nil {
+		if response != nil {
+			response.RawDump()
+		}
+		return "", gerror.Wrap(err, "提交任务失败，POST 请求发生错误")
+	}
+	defer response.Close()
+
+	bodyStr := response.ReadAllString()
+	if response.Response.Header.Get("X-Api-Message") != "OK" {
+		statusCode := response.Response.Header.Get("X-Api-Status-Code")
+		logid := response.Response.Header.Get("X-Tt-Logid")
+		g.Log().Errorf(ctx, "[%s] 任务提交失败。StatusCode=%s Message=%s Mapped=%s Logid=%s Body=%s",
+			larkRequestId,
+			statusCode,
+			response.Response.Header.Get("X-Api-Message"),
+			consts.GetErrMsg(ctx, statusCode),
+			logid,
+			gstr.StrLimitRune(bodyStr, 500, "..."),
+		)
+		return "", gerror.Newf("第三方服务返回非OK。StatusCode=%s Message=%s Logid=%s",
+			statusCode,
+			response.Response.Header.Get("X-Api-Message"),
+			logid,
+		)
+	}
+	return gjson.New(bodyStr).Get("Data.TaskID").String(), nil
+}
+
+// Reprocess 用新的参数重新处理已结束的任务。同一时间只能有一个版本在处理中，由 Submit 检查。
+func Reprocess(ctx context.Context, record *entity.Transcription, params v1.TaskSubmitParams, author string, autoActivate bool) (*entity.TranscriptionVersion, error) {
+	switch record.Status {
+	case StatusSuccess, StatusPartialSuccess, "failed":
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidOperation, "任务尚未处理完成，无法重新处理。当前状态：%s", record.Status)
+	}
+	return Submit(ctx, record, params, author, autoActivate)
+}
+
+// Versions 返回任务的全部结果版本，按版本号升序。
+func Versions(ctx context.Context, requestId string) ([]*entity.TranscriptionVersion, error) {
+	cols := dao.TranscriptionVersion.Columns()
+	var versions []*entity.TranscriptionVersion
+	if err := dao.TranscriptionVersion.Ctx(ctx).
+		Fields(cols.Version, cols.TaskId, cols.TaskParams, cols.Status, cols.FetchErrors, cols.Duration,
+			cols.AutoActivate, cols.CreatedBy, cols.UpdatedAt, cols.CreatedAt).
+		Where(cols.RequestId+" = ?", requestId).
+		OrderAsc(cols.Version).
+		Scan(&versions); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询结果版本失败")
+	}
+	return versions, nil
+}
+
+// versionByTask 按火山云任务ID读取版本。找不到时返回 nil。
+func versionByTask(ctx context.Context, taskId string) (*entity.TranscriptionVersion, error) {
+	var version *entity.TranscriptionVersion
+	if err := dao.TranscriptionVersion.Ctx(ctx).
+		Where(dao.TranscriptionVersion.Columns().TaskId+" = ?", taskId).
+		Limit(1).
+		Scan(&version); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeDbOperationError, err, "查询结果版本失败")
+	}
+	return version, nil
+}
+
+// Activate 把指定版本设为任务的当前版本：复制该版本的参数、状态和类型化结果到任务记录，
+// 并重建检索文本、待办和对话统计。转写修正和说话人映射按句子ID和说话人ID继续生效，
+// 新版本中不存在的句子的修正会被忽略。
+func Activate(ctx context.Context, requestId string, version int) error {
+	cols := dao.TranscriptionVersion.Columns()
+	var row *entity.TranscriptionVersion
+	if err := dao.TranscriptionVersion.Ctx(ctx).
+		Where(cols.RequestId+" = ?", requestId).
+		Where(cols.Version+" = ?", version).
+		Limit(1).
+		Scan(&row); err != nil {
+		return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询结果版本失败")
+	}
+	if row == nil {
+		return gerror.NewCodef(gcode.CodeNotFound, "结果版本不存在：%d", version)
+	}
+	if row.Status != StatusSuccess && row.Status != StatusPartialSuccess {
+		return gerror.NewCodef(gcode.CodeInvalidOperation, "只能切换到处理成功的版本。版本 %d 的状态：%s", version, row.Status)
+	}
+
+	tcols := dao.Transcription.Columns()
+	data := g.Map{
+		tcols.Version:       row.Version,
+		tcols.TaskId:        row.TaskId,
+		tcols.TaskParams:    jsonValue(row.TaskParams),
+		tcols.Status:        row.Status,
+		tcols.FetchErrors:   jsonValue(row.FetchErrors),
+		tcols.ResultArchive: jsonValue(row.ResultArchive),
+	}
+	if row.Duration > 0 {
+		data[tcols.Duration] = keepDuration(row.Duration)
+	}
+	if _, err := dao.Transcription.Ctx(ctx).
+		Data(data).
+		Where(tcols.RequestId+" = ?", requestId).
+		Update(); err != nil {
+		return gerror.WrapCode(gcode.CodeDbOperationError, err, "切换结果版本失败")
+	}
+
+	// 检索文本和待办失败只记录日志，不影响切换
+	record, result, err := LoadResult(ctx, requestId)
+	if err != nil || result == nil {
+		g.Log().Warningf(ctx, "[%s] 切换到版本 %d 后读取结果失败：%v", requestId, version, err)
+		return nil
+	}
+	reindexText(ctx, record)
+	if err = todo.Extract(ctx, requestId, result); err != nil {
+		g.Log().Warningf(ctx, "[%s] 切换到版本 %d 后抽取待办失败：%v", requestId, version, err)
+	}
+	return nil
+}
+
+// jsonValue 把空的 JSON 字段转换为 NULL。
+func jsonValue(j *gjson.Json) any {
+	if j == nil || j.IsNil() {
+		return nil
+	}
+	return j
+}
+
+// probedDuration 是上传时 ffprobe 探测到的音频时长（毫秒），没有探测时为 NULL
+var probedDuration = fmt.Sprintf("(%s->'media_info'->>'duration')::BIGINT", dao.Transcription.Columns().FileInfo)
+
+// keepDuration 写入由转写结果得到的时长（最后一句的结束时间），上传时 ffprobe 探测到了时长的除外：
+// 探测到的是音频的实际时长，更准确，不覆盖。只用于任务表，结果版本表直接保存结果的时长。
+func keepDuration(duration int64) gdb.Raw {
+	col := dao.Transcription.Columns().Duration
+	return gdb.Raw(fmt.Sprintf("CASE WHEN %s > 0 THEN %s ELSE %d END", probedDuration, col, duration))
+}
+
+// effectiveDuration 返回按 keepDuration 规则更新后任务的时长，用于计算对话统计，与懒加载时使用任务记录上的时长一致。
+func effectiveDuration(ctx context.Context, requestId string, result *lark.Result) int64 {
+	value, err := dao.Transcription.Ctx(ctx).
+		Fields(probedDuration).
+		Where(dao.Transcription.Columns().RequestId, requestId).Value()
+	if err == nil && value.Int64() > 0 {
+		return value.Int64()
+	}
+	return result.Duration()
+}
@@ -0,0 +1,105 @@
+package transcription
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"golang.org/x/sync/singleflight"
+
+	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/service/media"
+	"doubao-speech-service/internal/service/volcengine"
+)
+
+const (
+	// WaveformSampleRate 是计算波形时的解码采样率
+	WaveformSampleRate = 16000
+	// WaveformSamplesPerPixel 是保存的波形的精度，请求的精度必须是它的整数倍
+	WaveformSamplesPerPixel = 256
+)
+
+// waveformGroup 合并同一任务的并发生成请求
+var waveformGroup singleflight.Group
+
+type waveformConfig struct {
+	FFmpegPath string
+	Timeout    time.Duration // 生成一个波形的超时
+}
+
+func loadWaveformConfig(ctx context.Context) waveformConfig {
+	tryFFMpeg, _ := exec.LookPath("ffmpeg")
+	return waveformConfig{
+		FFmpegPath: g.Cfg().MustGet(ctx, "media.waveform.ffmpeg", tryFFMpeg).String(),
+		Timeout:    g.Cfg().MustGet(ctx, "media.waveform.timeout", "5m").Duration(),
+	}
+}
+
+// GenerateWaveform 从 input 计算任务的波形并保存到 TOS。input 可以是本地路径或 URL。
+func GenerateWaveform(ctx context.Context, requestId, input string) (*media.Waveform, error) {
+	cfg := loadWaveformConfig(ctx)
+	if cfg.FFmpegPath == "" {
+		return nil, gerror.NewCode(gcode.CodeNotSupported, "未配置 ffmpeg，无法生成波形")
+	}
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	converter := media.NewConverter(cfg.FFmpegPath, media.ConvertOptions{})
+	waveform, err := converter.Waveform(ctx, input, WaveformSampleRate, WaveformSamplesPerPixel)
+	if err != nil {
+		// input 可能是预签名地址，ffmpeg 的输出中会带上它，只写日志
+		g.Log().Errorf(ctx, "[%s] 生成波形失败：%v", requestId, err)
+		return nil, gerror.NewCode(gcode.CodeInternalError, "生成波形失败")
+	}
+	if err = volcengine.PutBytes(ctx, volcengine.WaveformKey(requestId), waveform.Binary(16), "application/octet-stream"); err != nil {
+		return nil, err
+	}
+	return waveform, nil
+}
+
+// Waveform 读取任务的波形。上传时没有生成的（例如历史任务）从 TOS 中的原始音频生成并保存。
+func Waveform(ctx context.Context, record *entity.Transcription) (*media.Waveform, error) {
+	if record.FileInfo.Get("filename").String() == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "任务没有音频文件")
+	}
+	waveform, err, _ := waveformGroup.Do(record.RequestId, func() (any, error) {
+		// 生成结果由同一任务的所有请求共享，不随发起请求的客户端断开而取消，只受生成超时限制
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadWaveformConfig(ctx).Timeout)
+		defer cancel()
+		waveform, err := loadWaveform(ctx, record.RequestId)
+		if err != nil || waveform != nil {
+			return waveform, err
+		}
+		source, err := volcengine.GetFileURL(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		return GenerateWaveform(ctx, record.RequestId, source)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return waveform.(*media.Waveform), nil
+}
+
+// loadWaveform 读取 TOS 中保存的波形，不存在时返回 nil。
+func loadWaveform(ctx context.Context, requestId string) (*media.Waveform, error) {
+	key := volcengine.WaveformKey(requestId)
+	info, err := volcengine.StatObject(ctx, key)
+	if err != nil || info == nil {
+		return nil, err
+	}
+	body, err := volcengine.OpenObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取波形失败")
+	}
+	return media.ParseWaveform(data)
+}
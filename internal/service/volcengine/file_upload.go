@@ -6,11 +6,14 @@ import (
 	"doubao-speech-service/internal/consts"
 	"doubao-speech-service/internal/dao"
 	"doubao-speech-service/internal/model/entity"
+	"doubao-speech-service/internal/service/media"
 	"mime/multipart"
 	"os"
+	"os/exec"
 	"path/filepath"
 
 	"github.com/gabriel-vasile/mimetype"
+	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
@@ -33,9 +36,10 @@ type UploadSource interface {
 }
 
 // ProcessFileUpload 处理单个文件的上传
+// mediaInfo 是调用方已经探测到的媒体信息（见 ProbeMedia），为 nil 时不检查也不记录
 // requestID 为可选参数，如果提供则使用该 ID，否则生成新的 ID
 // 如果记录已存在则更新，否则插入新记录
-func ProcessFileUpload(ctx context.Context, file UploadSource, upn string, requestID ...string) FileUploadResult {
+func ProcessFileUpload(ctx context.Context, file UploadSource, upn string, mediaInfo *media.Info, requestID ...string) FileUploadResult {
 	result := FileUploadResult{
 		FileName: file.FileName(),
 	}
@@ -43,6 +47,11 @@ func ProcessFileUpload(ctx context.Context, file UploadSource, upn string, reque
 		result.Error = gerror.Newf("文件大小超过最大限制：%d / 1,073,741,824 字节", file.FileSize())
 		return result
 	}
+	// 拒绝没有音频流的文件（例如无声视频）
+	if err := CheckMedia(mediaInfo); err != nil {
+		result.Error = err
+		return result
+	}
 
 	// 打开文件
 	fileReader, err := file.Open()
@@ -86,15 +95,21 @@ func ProcessFileUpload(ctx context.Context, file UploadSource, upn string, reque
 		return result
 	}
 
-	// 更新 file_type（通过 mimetype 检测得到的真实类型）
-	if _, err := dao.Transcription.Ctx(ctx).Data(g.Map{
-		"file_info": g.Map{
-			"object_key": fileID + "/" + file.FileName(),
-			"filename":   file.FileName(),
-			"file_type":  mType.Extension(), // 通过 mimetype 检测的真实类型
-			"file_size":  file.FileSize(),
-		},
-	}).Where("request_id = ?", fileID).Update(); err != nil {
+	// 更新 file_type（通过 mimetype 检测得到的真实类型）和探测到的媒体信息
+	fileInfo := g.Map{
+		"object_key": fileID + "/" + file.FileName(),
+		"filename":   file.FileName(),
+		"file_type":  mType.Extension(), // 通过 mimetype 检测的真实类型
+		"file_size":  file.FileSize(),
+	}
+	fileData := g.Map{"file_info": fileInfo}
+	if mediaInfo != nil {
+		fileInfo["media_info"] = mediaInfo
+		if mediaInfo.Duration > 0 {
+			fileData["duration"] = mediaInfo.Duration
+		}
+	}
+	if _, err := dao.Transcription.Ctx(ctx).Data(fileData).Where("request_id = ?", fileID).Update(); err != nil {
 		result.Error = gerror.Wrap(err, "更新数据库文件类型失败")
 		return result
 	}
@@ -150,12 +165,14 @@ func ProcessFileUpload(ctx context.Context, file UploadSource, upn string, reque
 	}
 
 	result.TaskMeta = v1.TaskMeta{
-		RequestId:  record.RequestId,
-		Owner:      record.Owner,
-		FileInfo:   record.FileInfo,
-		Status:     record.Status,
-		TaskParams: record.TaskParams,
-		CreatedAt:  record.CreatedAt,
+		RequestId:   record.RequestId,
+		Owner:       record.Owner,
+		WorkspaceId: record.WorkspaceId,
+		Duration:    record.Duration,
+		FileInfo:    record.FileInfo,
+		Status:      record.Status,
+		TaskParams:  record.TaskParams,
+		CreatedAt:   record.CreatedAt,
 	}
 
 	return result
@@ -208,3 +225,29 @@ func (r *localUploadFile) FileSize() int64 {
 func (r *localUploadFile) Open() (multipart.File, error) {
 	return os.Open(r.path)
 }
+
+// ProbeMedia 用 ffprobe 探测本地文件的媒体信息。每个文件只需探测一次，结果随上传流程传递。
+// 未配置 ffprobe 或探测失败时只记录日志并返回 nil，不影响上传，由转写服务判断文件是否可用。
+func ProbeMedia(ctx context.Context, path string) *media.Info {
+	tryFFprobe, _ := exec.LookPath("ffprobe")
+	binPath := g.Cfg().MustGet(ctx, "media.probe.ffprobe", tryFFprobe).String()
+	if binPath == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, g.Cfg().MustGet(ctx, "media.probe.timeout", "30s").Duration())
+	defer cancel()
+	info, err := media.NewProbe(binPath).Probe(ctx, path)
+	if err != nil {
+		g.Log().Warningf(ctx, "探测媒体信息失败，%s: %v", filepath.Base(path), err)
+		return nil
+	}
+	return info
+}
+
+// CheckMedia 检查探测到的媒体信息，文件没有音频流时返回 CodeInvalidParameter 错误。info 为 nil（没有探测）时不检查。
+func CheckMedia(info *media.Info) error {
+	if info != nil && !info.HasAudio() {
+		return gerror.NewCode(gcode.CodeInvalidParameter, "文件中没有音频")
+	}
+	return nil
+}
@@ -11,13 +11,16 @@ import (
 	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
 )
 
+// FileKey 返回任务原始音频在 TOS 中的路径。
+func FileKey(transRecord *entity.Transcription) string {
+	return transRecord.RequestId + "/" + transRecord.FileInfo.Get("filename").String()
+}
+
 // 根据任务记录获取文件直链地址
 func GetFileURL(ctx context.Context, transRecord *entity.Transcription) (string, error) {
 	tosC := GetClient()
 
-	requestId := transRecord.RequestId
-	fileName := transRecord.FileInfo.Get("filename").String()
-	key := requestId + "/" + fileName
+	key := FileKey(transRecord)
 	url, err := tosC.PreSignedURL(&tos.PreSignedURLInput{
 		HTTPMethod: enum.HttpMethodGet,
 		Bucket:     g.Cfg().MustGet(ctx, "volc.tos.bucket").String(),
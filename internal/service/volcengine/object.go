@@ -0,0 +1,179 @@
+package volcengine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// ClipKey 返回音频片段在 TOS 中的缓存路径。
+func ClipKey(requestId, name string) string {
+	return requestId + "/clips/" + name
+}
+
+// WaveformKey 返回任务波形数据在 TOS 中的路径。
+func WaveformKey(requestId string) string {
+	return requestId + "/waveform.dat"
+}
+
+// ObjectInfo 是对象的元数据。
+type ObjectInfo struct {
+	Size         int64
+	ETag         string // 带引号
+	LastModified time.Time
+	ContentType  string
+}
+
+// StatObject 读取对象的元数据。对象不存在时返回 nil。
+func StatObject(ctx context.Context, key string) (*ObjectInfo, error) {
+	out, err := GetClient().HeadObjectV2(ctx, &tos.HeadObjectV2Input{
+		Bucket: g.Cfg().MustGet(ctx, "volc.tos.bucket").String(),
+		Key:    key,
+	})
+	if err != nil {
+		if tos.StatusCode(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, gerror.Wrap(err, "查询文件失败")
+	}
+	return &ObjectInfo{
+		Size:         out.ContentLength,
+		ETag:         out.ETag,
+		LastModified: out.LastModified,
+		ContentType:  out.ContentType,
+	}, nil
+}
+
+// PutFile 把本地文件上传到 TOS。
+func PutFile(ctx context.Context, key, path, contentType string) error {
+	if _, err := GetClient().PutObjectFromFile(ctx, &tos.PutObjectFromFileInput{
+		PutObjectBasicInput: tos.PutObjectBasicInput{
+			Bucket:      g.Cfg().MustGet(ctx, "volc.tos.bucket").String(),
+			Key:         key,
+			ContentType: contentType,
+		},
+		FilePath: path,
+	}); err != nil {
+		return gerror.Wrap(err, "上传文件失败")
+	}
+	return nil
+}
+
+// PutBytes 把内存中的数据上传到 TOS。
+func PutBytes(ctx context.Context, key string, data []byte, contentType string) error {
+	if _, err := GetClient().PutObjectV2(ctx, &tos.PutObjectV2Input{
+		PutObjectBasicInput: tos.PutObjectBasicInput{
+			Bucket:      g.Cfg().MustGet(ctx, "volc.tos.bucket").String(),
+			Key:         key,
+			ContentType: contentType,
+		},
+		Content: bytes.NewReader(data),
+	}); err != nil {
+		return gerror.Wrap(err, "上传文件失败")
+	}
+	return nil
+}
+
+// OpenObject 读取对象内容，调用方负责关闭。
+func OpenObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := GetClient().GetObjectV2(ctx, &tos.GetObjectV2Input{
+		Bucket: g.Cfg().MustGet(ctx, "volc.tos.bucket").String(),
+		Key:    key,
+	})
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取文件失败")
+	}
+	return out.Content, nil
+}
+
+// ObjectReader 按需读取对象，实现 io.ReadSeeker，可以交给 http.ServeContent 处理 Range 请求。
+// Seek 不发起请求，Seek 之后的第一次 Read 从当前位置开始读取对象的剩余部分。
+type ObjectReader struct {
+	ctx    context.Context
+	key    string
+	info   *ObjectInfo
+	offset int64
+	body   io.ReadCloser
+}
+
+// NewObjectReader 创建对象的读取器。info 由 StatObject 取得，读取时用其中的 ETag 确保对象没有被替换。
+func NewObjectReader(ctx context.Context, key string, info *ObjectInfo) *ObjectReader {
+	return &ObjectReader{ctx: ctx, key: key, info: info}
+}
+
+func (r *ObjectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.info.Size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		out, err := GetClient().GetObjectV2(r.ctx, &tos.GetObjectV2Input{
+			Bucket:  g.Cfg().MustGet(r.ctx, "volc.tos.bucket").String(),
+			Key:     r.key,
+			Range:   fmt.Sprintf("bytes=%d-", r.offset),
+			IfMatch: r.info.ETag,
+		})
+		if err != nil {
+			return 0, gerror.Wrap(err, "读取文件失败")
+		}
+		r.body = out.Content
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *ObjectReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.info.Size
+	}
+	if offset < 0 {
+		return 0, gerror.New("seek 位置不能为负")
+	}
+	if offset != r.offset {
+		_ = r.Close()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+// Close 关闭正在进行的读取。
+func (r *ObjectReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
+
+// SignedURL 返回对象的预签名下载地址，expires 为有效期（秒）。downloadName 不为空时浏览器按附件下载并使用该文件名。
+func SignedURL(ctx context.Context, key string, expires int64, downloadName string) (string, error) {
+	input := &tos.PreSignedURLInput{
+		HTTPMethod: enum.HttpMethodGet,
+		Bucket:     g.Cfg().MustGet(ctx, "volc.tos.bucket").String(),
+		Key:        key,
+		Expires:    expires,
+	}
+	if downloadName != "" {
+		input.Query = map[string]string{
+			"response-content-disposition": "attachment; filename*=UTF-8''" + url.PathEscape(downloadName),
+		}
+	}
+	out, err := GetClient().PreSignedURL(input)
+	if err != nil {
+		return "", gerror.Wrap(err, "获取文件访问地址失败")
+	}
+	return out.SignedUrl, nil
+}
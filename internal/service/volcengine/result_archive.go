@@ -0,0 +1,50 @@
+package volcengine
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos"
+)
+
+// ResultArchiveKey 返回任务结果文件在 TOS 中的归档路径。每次处理（火山云任务）的结果分开归档。
+func ResultArchiveKey(requestId, taskId, name string) string {
+	return requestId + "/results/" + taskId + "/" + name + ".json"
+}
+
+// ArchiveResult 把火山云返回的原始结果文件归档到 TOS，返回对象路径。
+// 结果文件的下载链接有有效期，归档后可以随时重新解析。
+func ArchiveResult(ctx context.Context, requestId, taskId, name string, data []byte) (string, error) {
+	key := ResultArchiveKey(requestId, taskId, name)
+	if _, err := GetClient().PutObjectV2(ctx, &tos.PutObjectV2Input{
+		PutObjectBasicInput: tos.PutObjectBasicInput{
+			Bucket:      g.Cfg().MustGet(ctx, "volc.tos.bucket").String(),
+			Key:         key,
+			ContentType: "application/json",
+		},
+		Content: bytes.NewReader(data),
+	}); err != nil {
+		return "", gerror.Wrap(err, "归档结果文件失败")
+	}
+	return key, nil
+}
+
+// LoadArchivedResult 读取归档的原始结果文件。
+func LoadArchivedResult(ctx context.Context, key string) ([]byte, error) {
+	out, err := GetClient().GetObjectV2(ctx, &tos.GetObjectV2Input{
+		Bucket: g.Cfg().MustGet(ctx, "volc.tos.bucket").String(),
+		Key:    key,
+	})
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取归档结果文件失败")
+	}
+	defer out.Content.Close()
+	data, err := io.ReadAll(out.Content)
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取归档结果文件失败")
+	}
+	return data, nil
+}
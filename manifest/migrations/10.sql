@@ -0,0 +1,12 @@
+-- 转写修订：句子级修正（文本、说话人、时间）作为覆盖层保存，不修改上游原始结果。
+-- 每个修订保存截至该修订的全部修正，修订号从 1 开始递增，0 表示上游原始结果
+CREATE TABLE IF NOT EXISTS transcription_revision (
+    id SERIAL PRIMARY KEY,
+    request_id TEXT NOT NULL,
+    revision INTEGER NOT NULL,
+    corrections JSONB NOT NULL DEFAULT '[]',
+    comment TEXT NOT NULL DEFAULT '',
+    author TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (request_id, revision)
+);
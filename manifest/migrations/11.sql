@@ -0,0 +1,46 @@
+-- 全文检索：把转写句子、全文总结、章节总结和待办拆成纯文本片段，用 pg_trgm 索引支持中文的模糊匹配。
+-- 由轮询写入结果和保存转写修正时维护（internal/service/transcription/text.go）。
+-- 注意：pg_trgm 要求数据库的 LC_CTYPE 不是 C，否则中文字符不会被拆分为三元组
+CREATE TABLE IF NOT EXISTS transcription_text (
+    id SERIAL PRIMARY KEY,
+    request_id TEXT NOT NULL,
+    kind TEXT NOT NULL, -- summary / chapter / todo / utterance
+    seq INTEGER NOT NULL, -- 同一任务内的顺序
+    ref TEXT NOT NULL DEFAULT '', -- 转写句子的 sentenceId
+    content TEXT NOT NULL,
+    start_time BIGINT NOT NULL DEFAULT 0, -- 毫秒，待办和全文总结为 0
+    end_time BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcription_text_request_id ON transcription_text (request_id, seq);
+CREATE INDEX IF NOT EXISTS idx_transcription_text_content_trgm ON transcription_text USING GIN (content gin_trgm_ops);
+
+-- 为已有 result 字段的任务回填（不含之后保存的转写修正，下次修改转写时会重建）
+INSERT INTO transcription_text (request_id, kind, seq, ref, content, start_time, end_time)
+SELECT s.request_id, s.kind, s.seq, s.ref, s.content, s.start_time, s.end_time
+FROM (
+    SELECT t.request_id, 'summary' AS kind, 0 AS seq, '' AS ref, t.result->'summary'->>'title' AS content, 0::BIGINT AS start_time, 0::BIGINT AS end_time
+    FROM transcription t
+    WHERE jsonb_typeof(t.result->'summary') = 'object'
+    UNION ALL
+    SELECT t.request_id, 'summary', 1, '', t.result->'summary'->>'paragraph', 0, 0
+    FROM transcription t
+    WHERE jsonb_typeof(t.result->'summary') = 'object'
+    UNION ALL
+    SELECT t.request_id, 'chapter', 100000 + c.ord::INTEGER, '',
+           CONCAT_WS('：', NULLIF(c.v->>'title', ''), NULLIF(c.v->>'summary', '')),
+           COALESCE((c.v->>'startTime')::BIGINT, 0), COALESCE((c.v->>'endTime')::BIGINT, 0)
+    FROM transcription t, jsonb_array_elements(t.result->'chapters') WITH ORDINALITY AS c(v, ord)
+    WHERE jsonb_typeof(t.result->'chapters') = 'array'
+    UNION ALL
+    SELECT t.request_id, 'todo', 200000 + d.ord::INTEGER, '', d.v->>'content', 0, 0
+    FROM transcription t, jsonb_array_elements(t.result->'todos') WITH ORDINALITY AS d(v, ord)
+    WHERE jsonb_typeof(t.result->'todos') = 'array'
+    UNION ALL
+    SELECT t.request_id, 'utterance', 300000 + u.ord::INTEGER, COALESCE(u.v->>'sentenceId', ''), u.v->>'content',
+           COALESCE((u.v->>'startTime')::BIGINT, 0), COALESCE((u.v->>'endTime')::BIGINT, 0)
+    FROM transcription t, jsonb_array_elements(t.result->'utterances') WITH ORDINALITY AS u(v, ord)
+    WHERE jsonb_typeof(t.result->'utterances') = 'array'
+) s
+WHERE COALESCE(s.content, '') <> ''
+  AND NOT EXISTS (SELECT 1 FROM transcription_text x WHERE x.request_id = s.request_id);
@@ -0,0 +1,2 @@
+-- 对话统计（internal/service/analytics.Analytics）缓存，任务成功时计算，转写修正或说话人映射变化后清空，读取时重新计算
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS analytics JSONB;
@@ -0,0 +1,33 @@
+-- 待办跟踪：任务成功时把信息提取中的 todo_list 抽取为独立的待办，可以标记完成、指派负责人、设置截止日期，跨会议查看
+CREATE TABLE IF NOT EXISTS todo (
+    id SERIAL PRIMARY KEY,
+    todo_id TEXT NOT NULL UNIQUE,
+    request_id TEXT NOT NULL,
+    seq INTEGER NOT NULL, -- 在 todo_list 中的序号，重新处理任务时按序号更新，保留用户修改的字段
+    content TEXT NOT NULL,
+    executor TEXT NOT NULL DEFAULT '', -- 上游识别的执行人（原文）
+    deadline TEXT NOT NULL DEFAULT '', -- 上游识别的截止时间（原文）
+    sentence_id TEXT NOT NULL DEFAULT '', -- 最相近的转写句子，找不到时为空
+    start_time BIGINT NOT NULL DEFAULT 0, -- 该句子的开始时间（毫秒）
+    assignee TEXT NOT NULL DEFAULT '', -- 负责人 UPN
+    due_date DATE,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    done_at TIMESTAMPTZ,
+    done_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (request_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_assignee ON todo (assignee) WHERE NOT done;
+CREATE INDEX IF NOT EXISTS idx_todo_due_date ON todo (due_date) WHERE NOT done;
+
+-- 为已有 result 字段的任务回填（不关联转写句子）
+INSERT INTO todo (todo_id, request_id, seq, content, executor, deadline)
+SELECT REPLACE(gen_random_uuid()::TEXT, '-', ''), t.request_id, (d.ord - 1)::INTEGER,
+       d.v->>'content', COALESCE(d.v->>'executor', ''), COALESCE(d.v->>'deadline', '')
+FROM transcription t, jsonb_array_elements(t.result->'todos') WITH ORDINALITY AS d(v, ord)
+WHERE jsonb_typeof(t.result->'todos') = 'array'
+  AND COALESCE(d.v->>'content', '') <> ''
+ON CONFLICT (request_id, seq) DO NOTHING;
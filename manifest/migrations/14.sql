@@ -0,0 +1,4 @@
+-- 结果文件下载：result_archive 记录归档到 TOS 的原始结果文件 {字段名: {key, size, fetchedAt}}，
+-- fetch_errors 记录最近一次下载失败的结果文件 {字段名: {attempts, status, message}}，此时任务状态为 partial_success
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS result_archive JSONB;
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS fetch_errors JSONB;
@@ -0,0 +1,35 @@
+-- 结果版本：每次向火山云提交任务（首次提交或重新处理）产生一个版本，保存该次的任务参数、状态和结果。
+-- transcription 表上的 task_id、task_params、status 和结果字段始终是当前版本的副本，切换当前版本时整体复制过去
+CREATE TABLE IF NOT EXISTS transcription_version (
+    id SERIAL PRIMARY KEY,
+    request_id TEXT NOT NULL,
+    version INTEGER NOT NULL, -- 从 1 开始递增
+    task_id TEXT NOT NULL UNIQUE,
+    task_params JSON,
+    status TEXT NOT NULL,
+    audio_transcription_file JSON,
+    chapter_file JSON,
+    information_extraction_file JSON,
+    summarization_file JSON,
+    translation_file JSON,
+    result JSONB,
+    fetch_errors JSONB,
+    result_archive JSONB,
+    duration BIGINT NOT NULL DEFAULT 0,
+    auto_activate BOOLEAN NOT NULL DEFAULT FALSE, -- 处理成功后自动设为当前版本
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (request_id, version)
+);
+
+-- 已提交过的任务回填为版本 1
+INSERT INTO transcription_version (request_id, version, task_id, task_params, status,
+    audio_transcription_file, chapter_file, information_extraction_file, summarization_file, translation_file,
+    result, fetch_errors, result_archive, duration, created_by, updated_at, created_at)
+SELECT request_id, 1, task_id, task_params, status,
+       audio_transcription_file, chapter_file, information_extraction_file, summarization_file, translation_file,
+       result, fetch_errors, result_archive, COALESCE(duration, 0), owner, updated_at, created_at
+FROM transcription
+WHERE COALESCE(task_id, '') <> ''
+ON CONFLICT DO NOTHING;
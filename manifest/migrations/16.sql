@@ -0,0 +1,62 @@
+-- 结果文件单独存放：原来的五个 JSON 结果字段移到 transcription_result 表，每个结果版本的每种结果文件一行（JSONB）。
+-- 列表查询不再读取结果内容。transcription.version 记录当前版本号，任务尚未提交时为 0
+CREATE TABLE IF NOT EXISTS transcription_result (
+    id SERIAL PRIMARY KEY,
+    request_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    kind TEXT NOT NULL, -- audio_transcription_file / chapter_file / information_extraction_file / summarization_file / translation_file
+    content JSONB NOT NULL,
+    size INTEGER NOT NULL, -- 原始结果文件的字节数
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (request_id, version, kind)
+);
+
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0;
+UPDATE transcription t SET version = v.version
+FROM transcription_version v
+WHERE v.task_id = t.task_id AND t.version = 0;
+
+-- 当前版本以任务记录上的结果为准，其余版本取版本记录上的结果
+INSERT INTO transcription_result (request_id, version, kind, content, size, fetched_at)
+SELECT t.request_id, t.version, f.kind, f.content::JSONB, OCTET_LENGTH(f.content::TEXT), t.updated_at
+FROM transcription t
+CROSS JOIN LATERAL (VALUES
+    ('audio_transcription_file', t.audio_transcription_file),
+    ('chapter_file', t.chapter_file),
+    ('information_extraction_file', t.information_extraction_file),
+    ('summarization_file', t.summarization_file),
+    ('translation_file', t.translation_file)
+) AS f(kind, content)
+WHERE t.version > 0 AND f.content IS NOT NULL
+ON CONFLICT (request_id, version, kind) DO NOTHING;
+
+INSERT INTO transcription_result (request_id, version, kind, content, size, fetched_at)
+SELECT v.request_id, v.version, f.kind, f.content::JSONB, OCTET_LENGTH(f.content::TEXT), v.updated_at
+FROM transcription_version v
+CROSS JOIN LATERAL (VALUES
+    ('audio_transcription_file', v.audio_transcription_file),
+    ('chapter_file', v.chapter_file),
+    ('information_extraction_file', v.information_extraction_file),
+    ('summarization_file', v.summarization_file),
+    ('translation_file', v.translation_file)
+) AS f(kind, content)
+WHERE f.content IS NOT NULL
+ON CONFLICT (request_id, version, kind) DO NOTHING;
+
+ALTER TABLE transcription
+    DROP COLUMN IF EXISTS audio_transcription_file,
+    DROP COLUMN IF EXISTS chapter_file,
+    DROP COLUMN IF EXISTS information_extraction_file,
+    DROP COLUMN IF EXISTS summarization_file,
+    DROP COLUMN IF EXISTS translation_file;
+ALTER TABLE transcription_version
+    DROP COLUMN IF EXISTS audio_transcription_file,
+    DROP COLUMN IF EXISTS chapter_file,
+    DROP COLUMN IF EXISTS information_extraction_file,
+    DROP COLUMN IF EXISTS summarization_file,
+    DROP COLUMN IF EXISTS translation_file;
+
+-- 任务参数改为 JSONB，与 file_info 一致
+ALTER TABLE transcription ALTER COLUMN task_params TYPE JSONB USING task_params::JSONB;
+ALTER TABLE transcription_version ALTER COLUMN task_params TYPE JSONB USING task_params::JSONB;
@@ -0,0 +1,3 @@
+-- 条件请求：change_seq 是任务的修改计数，转写修正、说话人映射等任务记录之外的数据变化时加 1。
+-- 任务的 ETag 由 updated_at 和 change_seq 计算
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT 0;
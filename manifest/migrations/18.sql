@@ -0,0 +1,16 @@
+-- 类型化结果也移到 transcription_result 表，作为 kind = 'result' 的一行，按需读取。
+-- 任务表和结果版本表上不再保存完整的类型化结果（包括单词级时间戳），列表和按行读取任务时不再携带大字段
+INSERT INTO transcription_result (request_id, version, kind, content, size, fetched_at)
+SELECT t.request_id, t.version, 'result', t.result, OCTET_LENGTH(t.result::TEXT), t.updated_at
+FROM transcription t
+WHERE t.version > 0 AND t.result IS NOT NULL
+ON CONFLICT (request_id, version, kind) DO NOTHING;
+
+INSERT INTO transcription_result (request_id, version, kind, content, size, fetched_at)
+SELECT v.request_id, v.version, 'result', v.result, OCTET_LENGTH(v.result::TEXT), v.updated_at
+FROM transcription_version v
+WHERE v.result IS NOT NULL
+ON CONFLICT (request_id, version, kind) DO NOTHING;
+
+ALTER TABLE transcription DROP COLUMN IF EXISTS result;
+ALTER TABLE transcription_version DROP COLUMN IF EXISTS result;
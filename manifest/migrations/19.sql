@@ -0,0 +1,9 @@
+-- 对话统计缓存移到 transcription_result 表，作为当前结果版本 kind = 'analytics' 的一行。
+-- 读取时补算统计不再写任务表，不会改变任务的 updated_at 和 ETag
+INSERT INTO transcription_result (request_id, version, kind, content, size, fetched_at)
+SELECT t.request_id, t.version, 'analytics', t.analytics, OCTET_LENGTH(t.analytics::TEXT), t.updated_at
+FROM transcription t
+WHERE t.version > 0 AND t.analytics IS NOT NULL
+ON CONFLICT (request_id, version, kind) DO NOTHING;
+
+ALTER TABLE transcription DROP COLUMN IF EXISTS analytics;
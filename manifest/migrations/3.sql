@@ -0,0 +1,29 @@
+-- 任务共享：授予其他用户 / 用户组查看或编辑权限
+CREATE TABLE IF NOT EXISTS transcription_share (
+    id SERIAL PRIMARY KEY,
+    request_id TEXT NOT NULL,
+    grantee_type TEXT NOT NULL, -- user: 用户 UPN；group: 用户组
+    grantee TEXT NOT NULL,
+    role TEXT NOT NULL, -- viewer：只读；editor：可编辑
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (request_id, grantee_type, grantee)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcription_share_grantee ON transcription_share(grantee_type, grantee);
+
+-- 公开分享链接：可设置过期时间、访问密码，可随时撤销
+CREATE TABLE IF NOT EXISTS transcription_share_link (
+    id SERIAL PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    request_id TEXT NOT NULL,
+    password_hash TEXT,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcription_share_link_request_id ON transcription_share_link(request_id);
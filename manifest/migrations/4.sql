@@ -0,0 +1,26 @@
+-- 团队空间：任务可以归属于部门或项目团队，而不只是某个用户
+CREATE TABLE IF NOT EXISTS workspace (
+    id SERIAL PRIMARY KEY,
+    workspace_id TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS workspace_member (
+    id SERIAL PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    member TEXT NOT NULL,
+    role TEXT NOT NULL, -- owner / admin / editor / viewer
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (workspace_id, member)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_member_member ON workspace_member(member);
+
+-- workspace_id 为空表示个人空间的任务
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS workspace_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_transcription_workspace_id ON transcription(workspace_id);
@@ -0,0 +1,10 @@
+-- 可编辑的任务元数据：标题、描述、标签、文件夹
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS title TEXT;
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS description TEXT;
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS folder TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_transcription_tags ON transcription USING GIN (tags jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_transcription_folder ON transcription(owner, folder);
+CREATE INDEX IF NOT EXISTS idx_transcription_title_trgm ON transcription USING GIN (COALESCE(title, '') gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_transcription_description_trgm ON transcription USING GIN (COALESCE(description, '') gin_trgm_ops);
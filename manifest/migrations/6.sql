@@ -0,0 +1,18 @@
+-- 任务时长（毫秒），用于列表筛选和排序
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS duration BIGINT;
+
+-- 已完成的任务根据转写结果最后一句的结束时间回填时长
+UPDATE transcription t
+SET duration = sub.duration
+FROM (
+    SELECT id, MAX((e->>'end_time')::BIGINT) AS duration
+    FROM transcription,
+        json_array_elements(CASE WHEN json_typeof(audio_transcription_file) = 'array' THEN audio_transcription_file ELSE '[]'::json END) e
+    GROUP BY id
+) sub
+WHERE t.id = sub.id AND t.duration IS NULL;
+
+-- 列表排序使用的表达式索引，需与 service/transcription/filter.go 中的排序表达式保持一致
+CREATE INDEX IF NOT EXISTS idx_transcription_owner_created_at ON transcription(owner, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transcription_owner_duration ON transcription(owner, (COALESCE(duration, 0)), id);
+CREATE INDEX IF NOT EXISTS idx_transcription_owner_title ON transcription(owner, (COALESCE(NULLIF(title, ''), file_info->>'filename', '')), id);
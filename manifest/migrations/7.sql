@@ -0,0 +1,2 @@
+-- 类型化的结果（internal/model/lark.Result），由轮询在任务成功时从原始结果文件解析生成
+ALTER TABLE transcription ADD COLUMN IF NOT EXISTS result JSONB;
@@ -0,0 +1,11 @@
+-- 会议纪要模板：按团队空间保存的自定义 Markdown 模板（Go text/template 语法）
+CREATE TABLE IF NOT EXISTS minutes_template (
+    id SERIAL PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    content TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (workspace_id, name)
+);
@@ -0,0 +1,15 @@
+-- 说话人映射：按任务把上游的说话人ID映射为真实姓名，或合并被错误拆分的说话人。不修改上游原始结果
+CREATE TABLE IF NOT EXISTS transcription_speaker (
+    id SERIAL PRIMARY KEY,
+    request_id TEXT NOT NULL,
+    speaker_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    profile TEXT NOT NULL DEFAULT '', -- 关联的用户 UPN，可为空
+    merge_into TEXT NOT NULL DEFAULT '', -- 合并到的说话人ID，为空表示不合并
+    updated_by TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (request_id, speaker_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcription_speaker_name_trgm ON transcription_speaker USING GIN (name gin_trgm_ops);